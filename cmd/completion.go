@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+// completionCmd represents the completion command
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate shell completion script",
+	Long: `Generate a shell completion script for k8s-controller.
+
+To load completions:
+
+Bash:
+  $ source <(k8s-controller completion bash)
+
+Zsh:
+  $ k8s-controller completion zsh > "${fpath[1]}/_k8s-controller"
+
+Fish:
+  $ k8s-controller completion fish | source
+
+PowerShell:
+  PS> k8s-controller completion powershell | Out-String | Invoke-Expression`,
+	DisableFlagsInUseLine: true,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		default:
+			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+	},
+}
+
+// completeNamespaces lists namespaces from the cluster for dynamic flag completion.
+// If the cluster is unreachable it silently returns no suggestions.
+func completeNamespaces(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	client := kubernetes.NewClient(clientOptions()...)
+	if err := client.Connect(ctx); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	namespaces, err := client.ListNamespaces(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return namespaces, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"sigs.k8s.io/yaml"
+
+	"k8s-controller/internal/infrastructure/config"
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the controller configuration",
+	Long:  `Inspect the configuration resolved from defaults, the config file, environment variables and flags`,
+}
+
+// configValidateCmd represents the config validate subcommand
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the configuration and print the effective values",
+	Long: `Load the configuration, print the effective values as YAML and validate them.
+Exits with a non-zero status if the configuration is invalid, so it can run in CI before a rollout.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+			os.Exit(1)
+		}
+
+		out, err := yaml.Marshal(cfg.Settings())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to render configuration: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(string(out))
+
+		if err := validateConfig(cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Configuration is invalid:\n%v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(os.Stderr, "Configuration is valid")
+	},
+}
+
+// configPrintCmd represents the config print subcommand
+var configPrintCmd = &cobra.Command{
+	Use:   "print",
+	Short: "Print each configuration key with its effective value and source",
+	Long: `Print every known configuration key, its effective value and where the value came from:
+flag, env, file or default.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+			os.Exit(1)
+		}
+
+		entries := configEntries(cfg.Settings(), "")
+		for i := range entries {
+			entries[i].source = configSource(cmd, entries[i].key)
+		}
+		printTable(configEntryColumns, entries, false)
+	},
+}
+
+// configEntry is a single resolved configuration key
+type configEntry struct {
+	key    string
+	value  string
+	source string
+}
+
+// configEntryColumns are the columns printed by config print
+var configEntryColumns = []column[configEntry]{
+	{header: "KEY", width: 40, value: func(e configEntry) string { return e.key }},
+	{header: "VALUE", width: 40, value: func(e configEntry) string { return e.value }},
+	{header: "SOURCE", width: 8, value: func(e configEntry) string { return e.source }},
+}
+
+// configEntries flattens nested settings into dotted keys sorted by name
+func configEntries(settings map[string]interface{}, prefix string) []configEntry {
+	var entries []configEntry
+	for name, value := range settings {
+		key := prefix + name
+		if nested, ok := value.(map[string]interface{}); ok {
+			entries = append(entries, configEntries(nested, key+".")...)
+			continue
+		}
+		entries = append(entries, configEntry{key: key, value: formatConfigValue(value)})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+	return entries
+}
+
+// formatConfigValue renders a setting value on a single line
+func formatConfigValue(value interface{}) string {
+	switch val := value.(type) {
+	case []string:
+		return strings.Join(val, ",")
+	case map[string]string:
+		if len(val) == 0 {
+			return ""
+		}
+		return formatLabels(val)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// configSource reports where viper resolved the key from, following viper's precedence:
+// a flag set on the command line, then the environment, then the config file, then the default.
+// Flags of other commands are not parsed here, so only persistent flags can be a source.
+func configSource(cmd *cobra.Command, key string) string {
+	if name, ok := persistentFlagKeys[key]; ok {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			return "flag"
+		}
+	}
+	if _, ok := os.LookupEnv(strings.ToUpper(key)); ok {
+		return "env"
+	}
+	if viper.InConfig(key) {
+		return "file"
+	}
+	return "default"
+}
+
+// loadConfig loads and validates the configuration for serve and control. Invalid settings and
+// errors that would change what is watched are fatal, other errors fall back to the default
+// configuration.
+func loadConfig() *config.Config {
+	cfg, err := config.Load()
+	if errors.Is(err, config.ErrListFile) || errors.Is(err, config.ErrNoNamespaces) {
+		slog.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+		return config.Default()
+	}
+
+	// Report and watch po, svc or deploy under their plural names
+	cfg.WatchedResources = kubernetes.NormalizeResourceTypes(cfg.WatchedResources)
+
+	// Settings such as a client CA without TLS would fail open if they were only checked by config validate
+	if err := cfg.Validate(); err != nil {
+		slog.Error("Invalid configuration", "error", err)
+		os.Exit(1)
+	}
+	return cfg
+}
+
+// validateConfig runs the config checks plus the resource type check done by control
+func validateConfig(cfg *config.Config) error {
+	err := cfg.Validate()
+	if !cfg.IgnoreUnknownResources {
+		err = errors.Join(err, kubernetes.ValidateResourceTypes(cfg.WatchedResources))
+	}
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configPrintCmd)
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestConfigEntriesFlattensSettings(t *testing.T) {
+	entries := configEntries(map[string]interface{}{
+		"server": map[string]interface{}{"port": 8080},
+		"kubernetes": map[string]interface{}{
+			"namespaces": []string{"default", "kube-system"},
+			"impersonate": map[string]interface{}{
+				"user": "jane",
+			},
+		},
+		"webhook": map[string]interface{}{
+			"default-labels": map[string]string{"tier": "web", "app": "nginx"},
+		},
+	}, "")
+
+	want := []configEntry{
+		{key: "kubernetes.impersonate.user", value: "jane"},
+		{key: "kubernetes.namespaces", value: "default,kube-system"},
+		{key: "server.port", value: "8080"},
+		{key: "webhook.default-labels", value: "app=nginx,tier=web"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries, got %+v", len(want), entries)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("entry %d: expected %+v, got %+v", i, want[i], entries[i])
+		}
+	}
+}
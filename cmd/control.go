@@ -2,18 +2,15 @@
 package cmd
 
 import (
-	"fmt"
 	"log/slog"
 	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"k8s-controller/internal/app"
-	"k8s-controller/internal/infrastructure/config"
+	"k8s-controller/internal/infrastructure/kubernetes"
 )
 
 // controlCmd represents the control command
@@ -23,17 +20,51 @@ var controlCmd = &cobra.Command{
 	Long: `Start the Kubernetes controller which will watch for resources
 and process them according to the defined business logic.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Starting Kubernetes controller...")
+		printInfo("Starting Kubernetes controller...")
 
 		// Load configuration
-		cfg, err := config.Load()
-		if err != nil {
-			slog.Error("Failed to load configuration", "error", err)
-			cfg = config.Default() // Use default config on error
+		cfg := loadConfig()
+
+		// Reject typos in --resources up front instead of silently watching nothing
+		if err := kubernetes.ValidateResourceTypes(cfg.WatchedResources); err != nil {
+			if !cfg.IgnoreUnknownResources {
+				slog.Error("Invalid resources", "error", err)
+				os.Exit(1)
+			}
+			slog.Warn("Ignoring unknown resources", "error", err)
+			cfg.WatchedResources = supportedResources(cfg.WatchedResources)
+			if len(cfg.WatchedResources) == 0 {
+				slog.Error("No supported resources to watch")
+				os.Exit(1)
+			}
+		}
+
+		// An invalid selector would make every informer list fail
+		if err := kubernetes.ValidateWatchSelector(cfg.WatchSelector); err != nil {
+			slog.Error("Invalid watch selector", "error", err)
+			os.Exit(1)
+		}
+		for resource, selector := range cfg.WatchSelectors {
+			if err := kubernetes.ValidateWatchSelector(selector); err != nil {
+				slog.Error("Invalid watch selector", "resource", resource, "error", err)
+				os.Exit(1)
+			}
 		}
 
+		summary := newStartupSummary("control", cfg)
+		summary.MetricsAddress = cfg.MetricsBindAddress
+		logStartupSummary(summary)
+
+		// Cancelled on SIGINT or SIGTERM
+		ctx, stop := signalContext()
+		defer stop()
+
+		// Export spans when otel.endpoint is set
+		flushTracing := setupTracing(ctx, cfg.OTelEndpoint)
+		defer flushTracing()
+
 		// Create controller with config
-		controller := app.NewKubernetesController(cfg)
+		controller := app.NewKubernetesController(cfg, clientOptions()...)
 
 		// Start controller
 		if err := controller.Start(); err != nil {
@@ -45,25 +76,44 @@ and process them according to the defined business logic.`,
 		slog.Info("Controller is running. Press Ctrl+C to stop.")
 
 		// Wait for termination signal
-		sigCh := make(chan os.Signal, 1)
-		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
-		<-sigCh
+		<-ctx.Done()
+		stop()
 
+		// Stop blocks until the controller goroutines have exited or the shutdown timeout passes
 		slog.Info("Shutting down controller...")
 		controller.Stop()
-
-		// Give time for graceful shutdown
-		time.Sleep(2 * time.Second)
-		slog.Info("Controller stopped")
 	},
 }
 
+// supportedResources drops the resource types the client can't watch, for --ignore-unknown-resources
+func supportedResources(resources []string) []string {
+	supported := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		if name, ok := kubernetes.NormalizeResourceType(resource); ok {
+			supported = append(supported, name)
+		}
+	}
+	return supported
+}
+
 func init() {
 	rootCmd.AddCommand(controlCmd)
 
 	// Add flags specific to controller functionality
 	controlCmd.Flags().StringSlice("namespaces", []string{"default"}, "Namespaces to watch (comma-separated)")
-	controlCmd.Flags().StringSlice("resources", []string{"deployments,services,pods"}, "Resources to watch (comma-separated)")
+	controlCmd.Flags().String("namespaces-file", "", "File with one namespace per line, added to --namespaces")
+	controlCmd.Flags().Bool("discover-namespaces", false, "Watch all namespaces in the cluster, discovered at startup")
+	controlCmd.Flags().Int("cluster-scope-threshold", 10, "Use one cluster-scoped informer factory when watching more than this many namespaces (0 disables)")
+	controlCmd.Flags().Bool("per-namespace-informers", false, "Always create one informer factory per namespace")
+	controlCmd.Flags().Duration("shutdown-timeout", 10*time.Second, "Maximum time to wait for the controller to stop and handle queued events (0 waits indefinitely)")
+	controlCmd.Flags().Duration("report-interval", 5*time.Minute, "Interval of the deployment health and policy report (0 disables)")
+	controlCmd.Flags().BoolVar(&startupBanner, "banner", false, "Print the startup summary to stdout")
+	controlCmd.Flags().Bool("check-permissions", true, "Verify list/watch permissions for watched resources before starting")
+	controlCmd.Flags().StringSlice("resources", []string{"deployments", "services", "pods"}, "Resources to watch (comma-separated)")
+	controlCmd.Flags().String("resources-file", "", "File with one resource per line, added to --resources")
+	controlCmd.Flags().Bool("ignore-unknown-resources", false, "Skip unsupported resource types instead of failing")
+	controlCmd.Flags().String("watch-selector", "", "Only watch and cache objects matching this label selector (e.g. team=payments)")
+	controlCmd.Flags().String("metrics-bind-address", ":8081", "Address to serve /metrics on (empty disables)")
 
 	// Add leader election flags
 	controlCmd.Flags().Bool("leader-elect", false, "Enable leader election for controller")
@@ -74,9 +124,42 @@ func init() {
 	if err := viper.BindPFlag("kubernetes.namespaces", controlCmd.Flags().Lookup("namespaces")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("kubernetes.namespaces-file", controlCmd.Flags().Lookup("namespaces-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("kubernetes.discover-namespaces", controlCmd.Flags().Lookup("discover-namespaces")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("kubernetes.cluster-scope-threshold", controlCmd.Flags().Lookup("cluster-scope-threshold")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("kubernetes.per-namespace-informers", controlCmd.Flags().Lookup("per-namespace-informers")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("kubernetes.resources", controlCmd.Flags().Lookup("resources")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("kubernetes.resources-file", controlCmd.Flags().Lookup("resources-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("kubernetes.ignore-unknown-resources", controlCmd.Flags().Lookup("ignore-unknown-resources")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("kubernetes.watch-selector", controlCmd.Flags().Lookup("watch-selector")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("metrics.bind-address", controlCmd.Flags().Lookup("metrics-bind-address")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("controller.shutdown-timeout", controlCmd.Flags().Lookup("shutdown-timeout")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("controller.report-interval", controlCmd.Flags().Lookup("report-interval")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("controller.check-permissions", controlCmd.Flags().Lookup("check-permissions")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("leader-election.enabled", controlCmd.Flags().Lookup("leader-elect")); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"k8s-controller/internal/infrastructure/config"
+)
+
+func TestControlDefaultWatchedResources(t *testing.T) {
+	want := []string{"deployments", "services", "pods"}
+
+	flagDefault, err := controlCmd.Flags().GetStringSlice("resources")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(flagDefault, want) {
+		t.Errorf("expected --resources to default to %v, got %v", want, flagDefault)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !slices.Equal(cfg.WatchedResources, want) {
+		t.Errorf("expected the effective watched resources to be %v, got %v", want, cfg.WatchedResources)
+	}
+}
+
+func TestSupportedResourcesDropsUnknownTypes(t *testing.T) {
+	got := supportedResources([]string{"deploy", "widgets", "configmaps"})
+	if want := []string{"deployments", "configmaps"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s-controller/internal/domain"
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+// describeCmd represents the describe command
+var describeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Show details of a Kubernetes resource",
+	Long:  `Show a human-readable summary of a single Kubernetes resource`,
+}
+
+// describeDeploymentCmd represents the describe deployment subcommand
+var describeDeploymentCmd = &cobra.Command{
+	Use:   "deployment <name>",
+	Short: "Describe a deployment",
+	Long:  `Show a detailed summary of a deployment in the specified namespace`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		// Create Kubernetes client
+		client := kubernetes.NewClient(clientOptions()...)
+
+		// Connect to cluster
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := client.Connect(ctx); err != nil {
+			slog.Error("Failed to connect to Kubernetes cluster", "error", err)
+			os.Exit(1)
+		}
+
+		// Get the deployment
+		deployment, err := client.GetDeployment(ctx, namespace, name)
+		if err != nil {
+			slog.Error("Failed to get deployment", "error", err, "name", name, "namespace", namespace)
+			os.Exit(1)
+		}
+
+		printDeploymentDescription(deployment)
+	},
+}
+
+// printDeploymentDescription prints a deployment in a kubectl describe-like format
+func printDeploymentDescription(deployment domain.Deployment) {
+	fmt.Printf("%-20s%s\n", "Name:", deployment.Name)
+	fmt.Printf("%-20s%s\n", "Namespace:", deployment.Namespace)
+	fmt.Printf("%-20s%s\n", "CreationTimestamp:", formatTimestamp(deployment.CreatedAt))
+	fmt.Printf("%-20s%s\n", "Age:", formatAge(deployment.CreatedAt))
+
+	// Print labels sorted by key for a stable output
+	if len(deployment.Labels) == 0 {
+		fmt.Printf("%-20s%s\n", "Labels:", "<none>")
+	} else {
+		keys := make([]string, 0, len(deployment.Labels))
+		for key := range deployment.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for i, key := range keys {
+			label := ""
+			if i == 0 {
+				label = "Labels:"
+			}
+			fmt.Printf("%-20s%s=%s\n", label, key, deployment.Labels[key])
+		}
+	}
+
+	fmt.Printf("%-20s%d desired | %d updated | %d ready | %d available | %d unavailable\n",
+		"Replicas:",
+		deployment.Replicas,
+		deployment.Status.UpdatedReplicas,
+		deployment.Status.ReadyReplicas,
+		deployment.Status.AvailableReplicas,
+		deployment.Status.UnavailableReplicas)
+
+	// Print conditions in a table like kubectl describe
+	if len(deployment.Status.Conditions) == 0 {
+		fmt.Printf("%-20s%s\n", "Conditions:", "<none>")
+		return
+	}
+
+	fmt.Println("Conditions:")
+	fmt.Printf("  %-16s%-8s%-28s%s\n", "Type", "Status", "Reason", "Message")
+	fmt.Printf("  %-16s%-8s%-28s%s\n", "----", "------", "------", "-------")
+	for _, condition := range deployment.Status.Conditions {
+		fmt.Printf("  %-16s%-8s%-28s%s\n", condition.Type, condition.Status, condition.Reason, condition.Message)
+	}
+}
+
+// formatAge returns a short human-readable age such as 5d, 3h or 42s. Timestamps slightly in
+// the future, from clock skew between the cluster and this machine, are shown as 0s.
+func formatAge(created time.Time) string {
+	if created.IsZero() {
+		return "<unknown>"
+	}
+
+	age := time.Since(created)
+	switch {
+	case age < 0:
+		return "0s"
+	case age >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	case age >= time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	case age >= time.Minute:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	default:
+		return fmt.Sprintf("%ds", int(age.Seconds()))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(describeCmd)
+	describeCmd.AddCommand(describeDeploymentCmd)
+
+	// Add namespace flag to the describe command
+	describeCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "default", "Kubernetes namespace")
+
+	// Complete namespace flag from the cluster when reachable
+	if err := describeCmd.RegisterFlagCompletionFunc("namespace", completeNamespaces); err != nil {
+		panic(fmt.Errorf("failed to register namespace completion: %w", err))
+	}
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTimestamp(t *testing.T) {
+	ts := time.Date(2025, 3, 4, 10, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	if got := formatTimestamp(ts); got != "2025-03-04T09:30:00Z" {
+		t.Errorf("expected the timestamp in UTC, got %s", got)
+	}
+	if got := formatTimestamp(time.Time{}); got != "<unknown>" {
+		t.Errorf("expected <unknown> for an unset timestamp, got %s", got)
+	}
+
+	localTime = true
+	defer func() { localTime = false }()
+	if got, want := formatTimestamp(ts), ts.Local().Format(time.RFC3339); got != want {
+		t.Errorf("expected the local timestamp %s with --local-time, got %s", want, got)
+	}
+}
+
+func TestFormatAgeClampsClockSkew(t *testing.T) {
+	if got := formatAge(time.Now().Add(30 * time.Second)); got != "0s" {
+		t.Errorf("expected a creation time in the future to show as 0s, got %s", got)
+	}
+	if got := formatAge(time.Now().Add(-90 * time.Second)); got != "1m" {
+		t.Errorf("expected 1m, got %s", got)
+	}
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+var diffFile string
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff -f manifest.yaml",
+	Short: "Show differences between a manifest and the live objects",
+	Long: `Compare each object in a manifest with the live object in the cluster and print a unified diff.
+Only fields set in the manifest are compared, so cluster defaults don't show up as drift.
+Exits with 0 when there are no differences, 1 when there are differences and 2 on errors.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		manifests, err := readManifests(diffFile)
+		if err != nil {
+			slog.Error("Failed to read manifest", "error", err, "file", diffFile)
+			os.Exit(2)
+		}
+
+		// Create Kubernetes client
+		client := kubernetes.NewClient(clientOptions()...)
+
+		// Connect to cluster
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := client.Connect(ctx); err != nil {
+			slog.Error("Failed to connect to Kubernetes cluster", "error", err)
+			os.Exit(2)
+		}
+
+		different := false
+		for _, manifest := range manifests {
+			if err := kubernetes.NormalizeManifest(manifest); err != nil {
+				slog.Error("Unsupported manifest", "error", err)
+				os.Exit(2)
+			}
+
+			kind, _, _ := unstructured.NestedString(manifest, "kind")
+			name, _, _ := unstructured.NestedString(manifest, "metadata", "name")
+			objectNamespace, _, _ := unstructured.NestedString(manifest, "metadata", "namespace")
+			if objectNamespace == "" {
+				objectNamespace = namespace
+			}
+
+			// A missing object is diffed against nothing, so the whole manifest shows as added
+			var live map[string]interface{}
+			resource, err := client.GetResource(ctx, kind, name, objectNamespace)
+			switch {
+			case err == nil:
+				live = resource.Data
+			case !apierrors.IsNotFound(err):
+				slog.Error("Failed to get live object", "error", err, "kind", kind, "name", name, "namespace", objectNamespace)
+				os.Exit(2)
+			}
+
+			diff, err := kubernetes.DiffManifest(manifest, live, fmt.Sprintf("%s/%s/%s", kind, objectNamespace, name))
+			if err != nil {
+				slog.Error("Failed to diff manifest", "error", err)
+				os.Exit(2)
+			}
+			if diff != "" {
+				different = true
+				fmt.Print(diff)
+			}
+		}
+
+		if different {
+			os.Exit(1)
+		}
+	},
+}
+
+// readManifests decodes every YAML or JSON document in the file, skipping empty documents
+func readManifests(path string) ([]map[string]interface{}, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var manifests []map[string]interface{}
+	decoder := utilyaml.NewYAMLOrJSONDecoder(file, 4096)
+	for {
+		var manifest map[string]interface{}
+		if err := decoder.Decode(&manifest); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		if len(manifest) == 0 {
+			continue
+		}
+		manifests = append(manifests, manifest)
+	}
+
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("no objects found in %s", path)
+	}
+	return manifests, nil
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringVarP(&diffFile, "filename", "f", "", "Manifest file to compare")
+	diffCmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Namespace for objects that don't set one")
+	if err := diffCmd.MarkFlagRequired("filename"); err != nil {
+		panic(fmt.Errorf("failed to mark filename flag required: %w", err))
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+var (
+	exportOutput    string
+	exportResources []string
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export resources as a YAML bundle",
+	Long: `Export resources in a namespace as a multi-document YAML manifest.
+Status and server-managed fields such as uid, resourceVersion and managedFields are removed,
+so the bundle can be applied again with kubectl apply -f.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Create Kubernetes client
+		client := kubernetes.NewClient(clientOptions()...)
+
+		// Connect to cluster
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := client.Connect(ctx); err != nil {
+			slog.Error("Failed to connect to Kubernetes cluster", "error", err)
+			os.Exit(1)
+		}
+
+		bundle, err := client.ExportManifests(ctx, namespace, exportResources)
+		if err != nil {
+			slog.Error("Failed to export resources", "error", err, "namespace", namespace)
+			os.Exit(1)
+		}
+
+		if exportOutput == "" || exportOutput == "-" {
+			fmt.Print(string(bundle))
+			return
+		}
+
+		if err := os.WriteFile(exportOutput, bundle, 0o644); err != nil {
+			slog.Error("Failed to write bundle", "error", err, "file", exportOutput)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Exported %v from namespace '%s' to %s\n", exportResources, namespace, exportOutput)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Kubernetes namespace")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "-", "File to write the bundle to, - for stdout")
+	exportCmd.Flags().StringSliceVar(&exportResources, "resources", []string{"deployments"}, "Resources to export (deployments, services, configmaps)")
+
+	// Complete namespace flag from the cluster when reachable
+	if err := exportCmd.RegisterFlagCompletionFunc("namespace", completeNamespaces); err != nil {
+		panic(fmt.Errorf("failed to register namespace completion: %w", err))
+	}
+}
@@ -5,21 +5,143 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/labels"
 
+	"k8s-controller/internal/domain"
 	"k8s-controller/internal/infrastructure/kubernetes"
 )
 
 var namespace string
+var showValues bool
+var sortBy string
+var labelSelector string
+
+// deploymentColumns defines the table columns for deployments
+var deploymentColumns = []column[domain.Deployment]{
+	{header: "NAME", width: 30, value: func(d domain.Deployment) string { return d.Name }},
+	{header: "NAMESPACE", width: 20, wide: true, value: func(d domain.Deployment) string { return d.Namespace }},
+	{header: "READY", width: 10, value: func(d domain.Deployment) string { return fmt.Sprint(d.ReadyReplicas) }},
+	{header: "UP-TO-DATE", width: 10, value: func(d domain.Deployment) string { return fmt.Sprint(d.UpdatedReplicas) }},
+	{header: "AVAILABLE", width: 10, value: func(d domain.Deployment) string { return fmt.Sprint(d.AvailableReplicas) }},
+	{header: "AGE", width: 8, wide: true, value: func(d domain.Deployment) string { return formatAge(d.CreatedAt) }},
+	{header: "IMAGES", width: 40, wide: true, value: func(d domain.Deployment) string { return strings.Join(d.Images, ",") }},
+	{header: "LABELS", labels: func(d domain.Deployment) map[string]string { return d.Labels }},
+}
+
+// configMapColumns defines the table columns for config maps
+var configMapColumns = []column[domain.ConfigMap]{
+	{header: "NAME", width: 40, value: func(c domain.ConfigMap) string { return c.Name }},
+	{header: "NAMESPACE", width: 20, wide: true, value: func(c domain.ConfigMap) string { return c.Namespace }},
+	{header: "DATA", width: 6, value: func(c domain.ConfigMap) string { return fmt.Sprint(len(c.DataKeys)) }},
+	{header: "KEYS", width: 40, value: func(c domain.ConfigMap) string { return strings.Join(c.DataKeys, ",") }},
+	{header: "LABELS", labels: func(c domain.ConfigMap) map[string]string { return c.Labels }},
+}
+
+// serviceColumns defines the table columns for services
+var serviceColumns = []column[domain.Service]{
+	{header: "NAME", width: 30, value: func(s domain.Service) string { return s.Name }},
+	{header: "NAMESPACE", width: 20, wide: true, value: func(s domain.Service) string { return s.Namespace }},
+	{header: "TYPE", width: 14, value: func(s domain.Service) string { return s.Type }},
+	{header: "CLUSTER-IP", width: 16, value: func(s domain.Service) string { return s.ClusterIP }},
+	{header: "PORTS", width: 20, value: func(s domain.Service) string { return strings.Join(s.Ports, ",") }},
+	{header: "AGE", width: 8, value: func(s domain.Service) string { return formatAge(s.CreatedAt) }},
+	{header: "LABELS", labels: func(s domain.Service) map[string]string { return s.Labels }},
+}
+
+// podColumns defines the table columns for pods
+var podColumns = []column[domain.Pod]{
+	{header: "NAME", width: 40, value: func(p domain.Pod) string { return p.Name }},
+	{header: "NAMESPACE", width: 20, wide: true, value: func(p domain.Pod) string { return p.Namespace }},
+	{header: "READY", width: 8, value: func(p domain.Pod) string { return fmt.Sprintf("%d/%d", p.ReadyContainers, p.TotalContainers) }},
+	{header: "STATUS", width: 12, value: func(p domain.Pod) string { return p.Phase }},
+	{header: "RESTARTS", width: 10, value: func(p domain.Pod) string { return fmt.Sprint(p.Restarts) }},
+	{header: "AGE", width: 8, value: func(p domain.Pod) string { return formatAge(p.CreatedAt) }},
+	{header: "NODE", width: 20, wide: true, value: func(p domain.Pod) string { return p.NodeName }},
+	{header: "LABELS", labels: func(p domain.Pod) map[string]string { return p.Labels }},
+}
+
+// ingressColumns defines the table columns for ingresses
+var ingressColumns = []column[domain.Ingress]{
+	{header: "NAME", width: 30, value: func(i domain.Ingress) string { return i.Name }},
+	{header: "NAMESPACE", width: 20, wide: true, value: func(i domain.Ingress) string { return i.Namespace }},
+	{header: "CLASS", width: 12, value: func(i domain.Ingress) string { return i.ClassName }},
+	{header: "HOSTS", width: 30, value: func(i domain.Ingress) string { return strings.Join(i.Hosts, ",") }},
+	{header: "BACKENDS", width: 30, value: func(i domain.Ingress) string { return formatIngressBackends(i.Paths) }},
+	{header: "TLS", width: 6, value: func(i domain.Ingress) string { return fmt.Sprint(len(i.TLSHosts) > 0) }},
+	{header: "AGE", width: 8, value: func(i domain.Ingress) string { return formatAge(i.CreatedAt) }},
+	{header: "LABELS", labels: func(i domain.Ingress) map[string]string { return i.Labels }},
+}
+
+// jobColumns defines the table columns for jobs
+var jobColumns = []column[domain.Job]{
+	{header: "NAME", width: 30, value: func(j domain.Job) string { return j.Name }},
+	{header: "NAMESPACE", width: 20, wide: true, value: func(j domain.Job) string { return j.Namespace }},
+	{header: "COMPLETIONS", width: 13, value: func(j domain.Job) string { return fmt.Sprintf("%d/%d", j.Succeeded, j.Completions) }},
+	{header: "ACTIVE", width: 8, value: func(j domain.Job) string { return fmt.Sprint(j.Active) }},
+	{header: "FAILED", width: 8, value: func(j domain.Job) string { return fmt.Sprint(j.Failed) }},
+	{header: "AGE", width: 8, value: func(j domain.Job) string { return formatAge(j.CreatedAt) }},
+	{header: "LABELS", labels: func(j domain.Job) map[string]string { return j.Labels }},
+}
+
+// cronJobColumns defines the table columns for cron jobs
+var cronJobColumns = []column[domain.CronJob]{
+	{header: "NAME", width: 30, value: func(c domain.CronJob) string { return c.Name }},
+	{header: "NAMESPACE", width: 20, wide: true, value: func(c domain.CronJob) string { return c.Namespace }},
+	{header: "SCHEDULE", width: 16, value: func(c domain.CronJob) string { return c.Schedule }},
+	{header: "SUSPEND", width: 9, value: func(c domain.CronJob) string { return fmt.Sprint(c.Suspend) }},
+	{header: "ACTIVE", width: 8, value: func(c domain.CronJob) string { return fmt.Sprint(c.Active) }},
+	{header: "LAST SCHEDULE", width: 15, value: func(c domain.CronJob) string { return formatOptionalAge(c.LastScheduleTime) }},
+	{header: "AGE", width: 8, value: func(c domain.CronJob) string { return formatAge(c.CreatedAt) }},
+	{header: "LABELS", labels: func(c domain.CronJob) map[string]string { return c.Labels }},
+}
+
+// formatOptionalAge formats the age of an optional timestamp, showing <none> when unset
+func formatOptionalAge(t *time.Time) string {
+	if t == nil {
+		return "<none>"
+	}
+	return formatAge(*t)
+}
+
+// formatIngressBackends renders the distinct backends of an ingress as name:port pairs
+func formatIngressBackends(paths []domain.IngressPath) string {
+	seen := make(map[string]bool, len(paths))
+	backends := make([]string, 0, len(paths))
+	for _, path := range paths {
+		backend := path.ServiceName
+		if path.ServicePort != "" {
+			backend += ":" + path.ServicePort
+		}
+		if !seen[backend] {
+			seen[backend] = true
+			backends = append(backends, backend)
+		}
+	}
+	return strings.Join(backends, ",")
+}
+
+// validateLabelSelector checks that the selector is well formed before any API call is made
+func validateLabelSelector(selector string) error {
+	if _, err := labels.Parse(selector); err != nil {
+		return fmt.Errorf("invalid label selector %q: %w", selector, err)
+	}
+	return nil
+}
 
 // listCmd represents the list command
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List Kubernetes resources",
 	Long:  `List Kubernetes resources like deployments, services, pods`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		setupLogger()
+		return validateOutputFormat(outputFormat)
+	},
 }
 
 // deploymentCmd represents the deployment subcommand
@@ -28,10 +150,20 @@ var deploymentCmd = &cobra.Command{
 	Short: "List deployments",
 	Long:  `List deployments in the specified namespace`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("Listing deployments in namespace: %s\n", namespace)
+		// Validate the sort key before contacting the cluster
+		if err := domain.ValidateDeploymentSortKey(sortBy); err != nil {
+			slog.Error("Invalid sort key", "error", err)
+			os.Exit(1)
+		}
+		if err := validateLabelSelector(labelSelector); err != nil {
+			slog.Error("Invalid label selector", "error", err)
+			os.Exit(1)
+		}
+
+		printInfo("Listing deployments in namespace: %s", namespace)
 
 		// Create Kubernetes client
-		client := kubernetes.NewClient()
+		client := kubernetes.NewClient(clientOptions()...)
 
 		// Connect to cluster
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -43,7 +175,7 @@ var deploymentCmd = &cobra.Command{
 		}
 
 		// List deployments
-		deployments, err := client.ListDeployments(ctx, namespace)
+		deployments, err := client.ListDeploymentsBySelector(ctx, namespace, labelSelector)
 		if err != nil {
 			slog.Error("Failed to list deployments", "error", err, "namespace", namespace)
 			os.Exit(1)
@@ -51,20 +183,283 @@ var deploymentCmd = &cobra.Command{
 
 		// Display results
 		if len(deployments) == 0 {
-			fmt.Printf("No deployments found in namespace '%s'\n", namespace)
+			printInfo("No deployments found in namespace '%s'", namespace)
+			return
+		}
+
+		_ = domain.SortDeployments(deployments, sortBy)
+
+		printInfo("Found %d deployment(s) in namespace '%s':", len(deployments), namespace)
+		printTable(deploymentColumns, deployments, outputFormat == "wide")
+	},
+}
+
+// serviceCmd represents the service subcommand
+var serviceCmd = &cobra.Command{
+	Use:   "service",
+	Short: "List services",
+	Long:  `List services in the specified namespace`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Validate the selector before contacting the cluster
+		if err := validateLabelSelector(labelSelector); err != nil {
+			slog.Error("Invalid label selector", "error", err)
+			os.Exit(1)
+		}
+
+		printInfo("Listing services in namespace: %s", namespace)
+
+		// Create Kubernetes client
+		client := kubernetes.NewClient(clientOptions()...)
+
+		// Connect to cluster
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := client.Connect(ctx); err != nil {
+			slog.Error("Failed to connect to Kubernetes cluster", "error", err)
+			os.Exit(1)
+		}
+
+		// List services
+		services, err := client.ListServices(ctx, namespace, labelSelector)
+		if err != nil {
+			slog.Error("Failed to list services", "error", err, "namespace", namespace)
+			os.Exit(1)
+		}
+
+		// Display results
+		if len(services) == 0 {
+			printInfo("No services found in namespace '%s'", namespace)
+			return
+		}
+
+		printInfo("Found %d service(s) in namespace '%s':", len(services), namespace)
+		printTable(serviceColumns, services, outputFormat == "wide")
+	},
+}
+
+// podCmd represents the pod subcommand
+var podCmd = &cobra.Command{
+	Use:   "pod",
+	Short: "List pods",
+	Long:  `List pods in the specified namespace`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Validate the selector before contacting the cluster
+		if err := validateLabelSelector(labelSelector); err != nil {
+			slog.Error("Invalid label selector", "error", err)
+			os.Exit(1)
+		}
+
+		printInfo("Listing pods in namespace: %s", namespace)
+
+		// Create Kubernetes client
+		client := kubernetes.NewClient(clientOptions()...)
+
+		// Connect to cluster
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := client.Connect(ctx); err != nil {
+			slog.Error("Failed to connect to Kubernetes cluster", "error", err)
+			os.Exit(1)
+		}
+
+		// List pods
+		pods, err := client.ListPods(ctx, namespace, labelSelector)
+		if err != nil {
+			slog.Error("Failed to list pods", "error", err, "namespace", namespace)
+			os.Exit(1)
+		}
+
+		// Display results
+		if len(pods) == 0 {
+			printInfo("No pods found in namespace '%s'", namespace)
+			return
+		}
+
+		printInfo("Found %d pod(s) in namespace '%s':", len(pods), namespace)
+		printTable(podColumns, pods, outputFormat == "wide")
+	},
+}
+
+// ingressCmd represents the ingress subcommand
+var ingressCmd = &cobra.Command{
+	Use:   "ingress",
+	Short: "List ingresses",
+	Long:  `List ingresses in the specified namespace`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Validate the selector before contacting the cluster
+		if err := validateLabelSelector(labelSelector); err != nil {
+			slog.Error("Invalid label selector", "error", err)
+			os.Exit(1)
+		}
+
+		printInfo("Listing ingresses in namespace: %s", namespace)
+
+		// Create Kubernetes client
+		client := kubernetes.NewClient(clientOptions()...)
+
+		// Connect to cluster
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := client.Connect(ctx); err != nil {
+			slog.Error("Failed to connect to Kubernetes cluster", "error", err)
+			os.Exit(1)
+		}
+
+		// List ingresses
+		ingresses, err := client.ListIngresses(ctx, namespace, labelSelector)
+		if err != nil {
+			slog.Error("Failed to list ingresses", "error", err, "namespace", namespace)
+			os.Exit(1)
+		}
+
+		// Display results
+		if len(ingresses) == 0 {
+			printInfo("No ingresses found in namespace '%s'", namespace)
 			return
 		}
 
-		fmt.Printf("Found %d deployment(s) in namespace '%s':\n", len(deployments), namespace)
-		fmt.Printf("%-30s %-10s %-10s %-10s\n", "NAME", "READY", "UP-TO-DATE", "AVAILABLE")
-		fmt.Println("--------------------------------------------------------------------------------")
+		printInfo("Found %d ingress(es) in namespace '%s':", len(ingresses), namespace)
+		printTable(ingressColumns, ingresses, outputFormat == "wide")
+	},
+}
+
+// jobCmd represents the job subcommand
+var jobCmd = &cobra.Command{
+	Use:   "job",
+	Short: "List jobs",
+	Long:  `List jobs in the specified namespace`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Validate the selector before contacting the cluster
+		if err := validateLabelSelector(labelSelector); err != nil {
+			slog.Error("Invalid label selector", "error", err)
+			os.Exit(1)
+		}
+
+		printInfo("Listing jobs in namespace: %s", namespace)
+
+		// Create Kubernetes client
+		client := kubernetes.NewClient(clientOptions()...)
+
+		// Connect to cluster
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := client.Connect(ctx); err != nil {
+			slog.Error("Failed to connect to Kubernetes cluster", "error", err)
+			os.Exit(1)
+		}
+
+		// List jobs
+		jobs, err := client.ListJobs(ctx, namespace, labelSelector)
+		if err != nil {
+			slog.Error("Failed to list jobs", "error", err, "namespace", namespace)
+			os.Exit(1)
+		}
 
-		for _, deployment := range deployments {
-			fmt.Printf("%-30s %-10d %-10d %-10d\n",
-				deployment.Name,
-				deployment.ReadyReplicas,
-				deployment.UpdatedReplicas,
-				deployment.AvailableReplicas)
+		// Display results
+		if len(jobs) == 0 {
+			printInfo("No jobs found in namespace '%s'", namespace)
+			return
+		}
+
+		printInfo("Found %d job(s) in namespace '%s':", len(jobs), namespace)
+		printTable(jobColumns, jobs, outputFormat == "wide")
+	},
+}
+
+// cronJobCmd represents the cronjob subcommand
+var cronJobCmd = &cobra.Command{
+	Use:   "cronjob",
+	Short: "List cron jobs",
+	Long:  `List cron jobs in the specified namespace`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Validate the selector before contacting the cluster
+		if err := validateLabelSelector(labelSelector); err != nil {
+			slog.Error("Invalid label selector", "error", err)
+			os.Exit(1)
+		}
+
+		printInfo("Listing cron jobs in namespace: %s", namespace)
+
+		// Create Kubernetes client
+		client := kubernetes.NewClient(clientOptions()...)
+
+		// Connect to cluster
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := client.Connect(ctx); err != nil {
+			slog.Error("Failed to connect to Kubernetes cluster", "error", err)
+			os.Exit(1)
+		}
+
+		// List cron jobs
+		cronJobs, err := client.ListCronJobs(ctx, namespace, labelSelector)
+		if err != nil {
+			slog.Error("Failed to list cron jobs", "error", err, "namespace", namespace)
+			os.Exit(1)
+		}
+
+		// Display results
+		if len(cronJobs) == 0 {
+			printInfo("No cron jobs found in namespace '%s'", namespace)
+			return
+		}
+
+		printInfo("Found %d cron job(s) in namespace '%s':", len(cronJobs), namespace)
+		printTable(cronJobColumns, cronJobs, outputFormat == "wide")
+	},
+}
+
+// configMapCmd represents the configmap subcommand
+var configMapCmd = &cobra.Command{
+	Use:   "configmap",
+	Short: "List config maps",
+	Long:  `List config maps in the specified namespace. Only data keys are shown unless --show-values is set.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		printInfo("Listing config maps in namespace: %s", namespace)
+
+		// Create Kubernetes client
+		client := kubernetes.NewClient(clientOptions()...)
+
+		// Connect to cluster
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := client.Connect(ctx); err != nil {
+			slog.Error("Failed to connect to Kubernetes cluster", "error", err)
+			os.Exit(1)
+		}
+
+		// List config maps
+		configMaps, err := client.ListConfigMaps(ctx, namespace)
+		if err != nil {
+			slog.Error("Failed to list config maps", "error", err, "namespace", namespace)
+			os.Exit(1)
+		}
+
+		// Display results
+		if len(configMaps) == 0 {
+			printInfo("No config maps found in namespace '%s'", namespace)
+			return
+		}
+
+		printInfo("Found %d config map(s) in namespace '%s':", len(configMaps), namespace)
+		printTable(configMapColumns, configMaps, outputFormat == "wide")
+
+		if showValues {
+			fmt.Println()
+			for _, configMap := range configMaps {
+				fmt.Printf("%s:\n", configMap.Name)
+				for _, key := range configMap.DataKeys {
+					if value, ok := configMap.Data[key]; ok {
+						fmt.Printf("    %s=%s\n", key, value)
+					}
+				}
+			}
 		}
 	},
 }
@@ -72,13 +467,41 @@ var deploymentCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(listCmd)
 	listCmd.AddCommand(deploymentCmd)
+	listCmd.AddCommand(serviceCmd)
+	listCmd.AddCommand(podCmd)
+	listCmd.AddCommand(configMapCmd)
+	listCmd.AddCommand(ingressCmd)
+	listCmd.AddCommand(jobCmd)
+	listCmd.AddCommand(cronJobCmd)
+
+	// Accept the plural and short names as well, e.g. list pods or list po
+	for _, c := range listCmd.Commands() {
+		c.Aliases = kubernetes.ResourceAliases(c.Name())
+	}
+
+	deploymentCmd.Flags().StringVar(&sortBy, "sort-by", domain.SortByName, "Sort deployments by name, age or ready")
+	for _, c := range []*cobra.Command{deploymentCmd, serviceCmd, podCmd, ingressCmd, jobCmd, cronJobCmd} {
+		c.Flags().StringVarP(&labelSelector, "selector", "l", "", "Label selector to filter on (e.g. app=web,tier in (frontend))")
+	}
+	configMapCmd.Flags().BoolVar(&showValues, "show-values", false, "Include config map data values in the output")
 
 	// Add namespace flag to both list and deployment commands
 	listCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "default", "Kubernetes namespace")
+	listCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format (wide adds namespace, age, images and truncated labels)")
+	listCmd.PersistentFlags().BoolVar(&showLabels, "show-labels", false, "Show all labels as the last column, without truncation")
+	listCmd.PersistentFlags().StringSliceVarP(&labelColumns, "label-columns", "L", nil, "Label keys to show as columns of their own (comma-separated)")
 	deploymentCmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Kubernetes namespace")
 
 	// Bind flags to viper
 	if err := viper.BindPFlag("kubernetes.namespace", listCmd.PersistentFlags().Lookup("namespace")); err != nil {
 		panic(fmt.Errorf("failed to bind namespace flag: %w", err))
 	}
+
+	// Complete namespace flags from the cluster when reachable
+	if err := listCmd.RegisterFlagCompletionFunc("namespace", completeNamespaces); err != nil {
+		panic(fmt.Errorf("failed to register namespace completion: %w", err))
+	}
+	if err := deploymentCmd.RegisterFlagCompletionFunc("namespace", completeNamespaces); err != nil {
+		panic(fmt.Errorf("failed to register namespace completion: %w", err))
+	}
 }
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// outputFormat selects the column set used by list commands
+var outputFormat string
+
+// showLabels prints the full LABELS column even without -o wide
+var showLabels bool
+
+// labelColumns are label keys printed as columns of their own
+var labelColumns []string
+
+// maxLabelsWidth is the width at which the LABELS column of -o wide is cut off
+const maxLabelsWidth = 60
+
+// column describes a single table column for items of type T
+type column[T any] struct {
+	header string
+	width  int
+	// wide columns are only shown with -o wide
+	wide  bool
+	value func(T) string
+	// labels marks the LABELS column, which is expanded according to --show-labels and --label-columns
+	labels func(T) map[string]string
+}
+
+// validateOutputFormat checks that the requested output format is supported
+func validateOutputFormat(format string) error {
+	switch format {
+	case "", "wide":
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q (supported: wide)", format)
+	}
+}
+
+// printTable prints items as a table using the given columns.
+// Wide columns are included only when wide is true.
+func printTable[T any](columns []column[T], items []T, wide bool) {
+	visible := make([]column[T], 0, len(columns))
+	for _, col := range columns {
+		if col.labels != nil {
+			visible = append(visible, expandLabelColumn(col, items, wide, showLabels, labelColumns)...)
+			continue
+		}
+		if !col.wide || wide {
+			visible = append(visible, col)
+		}
+	}
+
+	headers := make([]string, 0, len(visible))
+	for _, col := range visible {
+		headers = append(headers, fmt.Sprintf("%-*s", col.width, col.header))
+	}
+	fmt.Println(strings.TrimRight(strings.Join(headers, " "), " "))
+	fmt.Println("--------------------------------------------------------------------------------")
+
+	for _, item := range items {
+		cells := make([]string, 0, len(visible))
+		for _, col := range visible {
+			cells = append(cells, fmt.Sprintf("%-*s", col.width, col.value(item)))
+		}
+		fmt.Println(strings.TrimRight(strings.Join(cells, " "), " "))
+	}
+}
+
+// formatLabels formats a label map as sorted key=value pairs
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "<none>"
+	}
+
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// expandLabelColumn turns the LABELS column into one column per requested label key, followed
+// by the LABELS column itself. All labels are shown with show; -o wide shows them truncated.
+func expandLabelColumn[T any](col column[T], items []T, wide, show bool, keys []string) []column[T] {
+	labelsOf := col.labels
+	columns := make([]column[T], 0, len(keys)+1)
+
+	for _, key := range keys {
+		header := strings.ToUpper(key[strings.LastIndex(key, "/")+1:])
+		width := len(header)
+		for _, item := range items {
+			width = max(width, len(labelsOf(item)[key]))
+		}
+		columns = append(columns, column[T]{header: header, width: width, value: func(item T) string { return labelsOf(item)[key] }})
+	}
+
+	switch {
+	case show:
+		columns = append(columns, column[T]{header: col.header, value: func(item T) string { return formatLabels(labelsOf(item)) }})
+	case wide:
+		columns = append(columns, column[T]{header: col.header, value: func(item T) string { return truncate(formatLabels(labelsOf(item)), maxLabelsWidth) }})
+	}
+	return columns
+}
+
+// truncate cuts s to at most width characters, marking the cut with "..."
+func truncate(s string, width int) string {
+	if len(s) <= width {
+		return s
+	}
+	return s[:width-3] + "..."
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpandLabelColumn(t *testing.T) {
+	type item struct{ labels map[string]string }
+	labelsCol := column[item]{header: "LABELS", labels: func(i item) map[string]string { return i.labels }}
+
+	many := make(map[string]string)
+	for _, key := range []string{"app", "team", "tier", "version", "owner", "cost-center", "region"} {
+		many[key] = "some-long-value"
+	}
+	items := []item{{labels: many}, {labels: map[string]string{"app.kubernetes.io/name": "web"}}}
+
+	headers := func(columns []column[item]) string {
+		names := make([]string, 0, len(columns))
+		for _, col := range columns {
+			names = append(names, col.header)
+		}
+		return strings.Join(names, ",")
+	}
+
+	if got := expandLabelColumn(labelsCol, items, false, false, nil); len(got) != 0 {
+		t.Errorf("expected no label columns by default, got %s", headers(got))
+	}
+
+	wide := expandLabelColumn(labelsCol, items, true, false, nil)
+	if len(wide) != 1 || len(wide[0].value(items[0])) != maxLabelsWidth || !strings.HasSuffix(wide[0].value(items[0]), "...") {
+		t.Errorf("expected one truncated LABELS column with -o wide, got %q", wide[0].value(items[0]))
+	}
+
+	shown := expandLabelColumn(labelsCol, items, false, true, nil)
+	if len(shown) != 1 || shown[0].value(items[0]) != formatLabels(many) {
+		t.Errorf("expected the full labels with --show-labels")
+	}
+
+	keyed := expandLabelColumn(labelsCol, items, false, false, []string{"team", "app.kubernetes.io/name"})
+	if got := headers(keyed); got != "TEAM,NAME" {
+		t.Errorf("expected TEAM,NAME columns, got %s", got)
+	}
+	if keyed[0].value(items[0]) != "some-long-value" || keyed[1].value(items[1]) != "web" || keyed[1].value(items[0]) != "" {
+		t.Error("unexpected label column values")
+	}
+	if keyed[0].width != len("some-long-value") {
+		t.Errorf("expected the column to fit its widest value, got width %d", keyed[0].width)
+	}
+}
@@ -4,14 +4,28 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"k8s-controller/internal/infrastructure/kubernetes"
 )
 
 var cfgFile string
 var logLevel string
+var mock bool
+var mockFixtures string
+var quiet bool
+var localTime bool
+
+// persistentFlagKeys maps config keys to the persistent flags bound to them
+var persistentFlagKeys = map[string]string{
+	"config":    "config",
+	"log.level": "log-level",
+}
 
 // version will be set by main package
 var version = "dev"
@@ -65,6 +79,34 @@ func setupLogger() {
 	slog.Debug("Logger initialized", "level", level.String())
 }
 
+// formatTimestamp formats a timestamp as RFC3339 in UTC, or in the local time zone with
+// --local-time, showing <unknown> for unset times
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return displayTime(t).Format(time.RFC3339)
+}
+
+// displayTime returns t in the time zone timestamps are displayed in
+func displayTime(t time.Time) time.Time {
+	if localTime {
+		return t.Local()
+	}
+	return t.UTC()
+}
+
+// printInfo prints an informational message to stdout. With --quiet it is logged at debug
+// level instead, so stdout only carries the requested data.
+func printInfo(format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	if quiet {
+		slog.Debug(message)
+		return
+	}
+	fmt.Println(message)
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
@@ -78,14 +120,17 @@ func Execute() {
 func init() {
 	cobra.OnInitialize(initConfig)
 
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/k8s-config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is k8s-config.* in ., $XDG_CONFIG_HOME/k8s-controller, $HOME or /etc/k8s-controller)")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "INFO", "Set the logging level (DEBUG, INFO, WARN, ERROR)")
-
-	if err := viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config")); err != nil {
-		panic(fmt.Errorf("failed to bind config flag: %w", err))
-	}
-	if err := viper.BindPFlag("log.level", rootCmd.PersistentFlags().Lookup("log-level")); err != nil {
-		panic(fmt.Errorf("failed to bind log.level flag: %w", err))
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Suppress informational output, printing only requested data and errors")
+	rootCmd.PersistentFlags().BoolVar(&localTime, "local-time", false, "Display timestamps in the local time zone instead of UTC")
+	rootCmd.PersistentFlags().BoolVar(&mock, "mock", false, "Serve canned objects from a fixtures file instead of connecting to a cluster (or set "+kubernetes.MockEnvVar+"=1)")
+	rootCmd.PersistentFlags().StringVar(&mockFixtures, "mock-fixtures", "", "Fixtures file with Kubernetes objects for --mock (default: built-in fixtures)")
+
+	for key, flag := range persistentFlagKeys {
+		if err := viper.BindPFlag(key, rootCmd.PersistentFlags().Lookup(flag)); err != nil {
+			panic(fmt.Errorf("failed to bind %s flag: %w", key, err))
+		}
 	}
 }
 
@@ -95,20 +140,17 @@ func initConfig() {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
 	} else {
-		// Find current directory.
-		currentDir, err := os.Getwd()
-		cobra.CheckErr(err)
-
-		// Search config in home directory with name "k8s-config" (without extension).
-		viper.AddConfigPath(currentDir)
-		viper.SetConfigType("yaml")
+		// Search for k8s-config.* in each directory, the first match wins
+		for _, path := range configSearchPaths() {
+			viper.AddConfigPath(path)
+		}
 		viper.SetConfigName("k8s-config")
 	}
 
 	viper.AutomaticEnv() // read in environment variables that match
 
 	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil {
+	if err := viper.ReadInConfig(); err == nil && !quiet {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
 
@@ -116,4 +158,47 @@ func initConfig() {
 	if viper.IsSet("log.level") {
 		logLevel = viper.GetString("log.level")
 	}
+
+	if mockEnabled() {
+		slog.Warn("Mock mode enabled, no cluster is contacted", "fixtures", mockFixtures)
+	}
+}
+
+// mockEnabled reports whether --mock or the mock environment variable asks for mock mode
+func mockEnabled() bool {
+	return mock || kubernetes.MockRequestedByEnv()
+}
+
+// clientOptions returns the options for the Kubernetes clients created by commands, which
+// serve the mock fixtures instead of a real cluster in mock mode
+func clientOptions() []kubernetes.ClientOption {
+	if mockEnabled() {
+		return []kubernetes.ClientOption{kubernetes.WithMockFixtures(mockFixtures)}
+	}
+	return nil
+}
+
+// configSearchPaths returns the directories searched for k8s-config.*, in precedence order:
+// the current directory, $XDG_CONFIG_HOME/k8s-controller (default ~/.config/k8s-controller),
+// the home directory and /etc/k8s-controller
+func configSearchPaths() []string {
+	var paths []string
+
+	currentDir, err := os.Getwd()
+	cobra.CheckErr(err)
+	paths = append(paths, currentDir)
+
+	home, _ := os.UserHomeDir()
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" && home != "" {
+		configHome = filepath.Join(home, ".config")
+	}
+	if configHome != "" {
+		paths = append(paths, filepath.Join(configHome, "k8s-controller"))
+	}
+	if home != "" {
+		paths = append(paths, home)
+	}
+
+	return append(paths, "/etc/k8s-controller")
 }
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestConfigSearchPathsOrder(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{cwd, filepath.Join(home, "xdg", "k8s-controller"), home, "/etc/k8s-controller"}
+	if got := configSearchPaths(); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	// Without XDG_CONFIG_HOME the XDG default under the home directory is used
+	t.Setenv("XDG_CONFIG_HOME", "")
+	if got := configSearchPaths(); got[1] != filepath.Join(home, ".config", "k8s-controller") {
+		t.Errorf("expected the default XDG config dir, got %v", got)
+	}
+}
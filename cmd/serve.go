@@ -3,16 +3,14 @@ package cmd
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log/slog"
 	"os"
-	"os/signal"
-	"syscall"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	"k8s-controller/internal/infrastructure/config"
+	"k8s-controller/internal/infrastructure/controller"
 	"k8s-controller/internal/infrastructure/server"
 )
 
@@ -22,20 +20,70 @@ var serveCmd = &cobra.Command{
 	Short: "Start the HTTP server",
 	Long:  `Start the HTTP server for the Kubernetes controller API`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Starting HTTP server...")
+		printInfo("Starting HTTP server...")
 
 		// Load configuration
-		cfg, err := config.Load()
-		if err != nil {
-			slog.Error("Failed to load configuration", "error", err)
-			cfg = config.Default() // Use default config on error
+		cfg := loadConfig()
+
+		// Tie the server, the controller manager and signal handling to one context
+		ctx, stop := signalContext()
+		defer stop()
+
+		// The server shuts down inside StartWithContext; restore the default signal behaviour as
+		// soon as shutdown starts so a second signal terminates a hung shutdown
+		go func() {
+			<-ctx.Done()
+			stop()
+		}()
+
+		// Export spans when otel.endpoint is set
+		flushTracing := setupTracing(ctx, cfg.OTelEndpoint)
+		defer flushTracing()
+
+		// Serve only the informer-backed API when the controller manager is not wanted
+		// The controller manager needs a real cluster, so mock mode only serves the API
+		noController, _ := cmd.Flags().GetBool("no-controller")
+		if mockEnabled() && !noController {
+			slog.Info("Mock mode does not support the controller-runtime manager, serving the API only")
+			noController = true
 		}
 
-		// Create controller runtime server
-		srv, err := server.NewControllerRuntimeServer(cfg.ServerPort, cfg)
-		if err != nil {
-			slog.Error("Failed to create controller runtime server", "error", err)
-			os.Exit(1)
+		// Create controller runtime server, falling back to the API only without a cluster config
+		var srv *server.ControllerRuntimeServer
+		if !noController {
+			var err error
+			srv, err = server.NewControllerRuntimeServer(cfg.ServerPort, cfg)
+			if errors.Is(err, controller.ErrNoClusterConfig) {
+				slog.Warn("No cluster configuration for the controller-runtime manager, serving the API only", "error", err)
+				noController = true
+			} else if err != nil {
+				slog.Error("Failed to create controller runtime server", "error", err)
+				os.Exit(1)
+			}
+		}
+
+		// The API informers only cache deployments; the manager also reconciles pods
+		summary := newStartupSummary("serve", cfg)
+		summary.Port = cfg.ServerPort
+		summary.TLS = cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
+		summary.Resources = []string{"deployments"}
+		if !noController {
+			summary.Resources = append(summary.Resources, "pods")
+			summary.MetricsAddress, summary.HealthAddress = srv.Endpoints()
+			summary.LeaderElection = cfg.EnableLeaderElection
+			summary.Webhook = cfg.WebhookEnabled
+		}
+		logStartupSummary(summary)
+
+		if noController {
+			slog.Info("Starting without controller-runtime manager")
+			apiServer := server.NewServerWithConfig(cfg, clientOptions()...)
+
+			slog.Info("Setting up routes and connecting to Kubernetes...")
+			apiServer.SetupRoutes()
+
+			runServer(ctx, apiServer, cfg.ServerPort)
+			return
 		}
 
 		// Setup routes - this will also connect to Kubernetes
@@ -43,7 +91,6 @@ var serveCmd = &cobra.Command{
 		srv.SetupRoutes()
 
 		// Register controllers with controller-runtime
-		ctx := context.Background()
 		if err := srv.RegisterControllers(ctx); err != nil {
 			slog.Error("Failed to register controllers", "error", err)
 			os.Exit(1)
@@ -53,26 +100,23 @@ var serveCmd = &cobra.Command{
 		srv.SetupControllerRuntimeRoutes()
 		slog.Info("Routes configured successfully")
 
-		// Handle graceful shutdown
-		go func() {
-			sigCh := make(chan os.Signal, 1)
-			signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
-
-			<-sigCh
-			slog.Info("Received shutdown signal")
+		runServer(ctx, srv, cfg.ServerPort)
+	},
+}
 
-			if err := srv.Shutdown(); err != nil {
-				slog.Error("Error shutting down server", "error", err)
-			}
-		}()
+// httpServer is implemented by both the base server and the controller-runtime server
+type httpServer interface {
+	StartWithContext(ctx context.Context) error
+}
 
-		// Start the server
-		slog.Info("Starting server", "port", cfg.ServerPort)
-		if err := srv.Start(); err != nil {
-			slog.Error("Failed to start server", "error", err)
-			os.Exit(1)
-		}
-	},
+// runServer runs the server until it fails or ctx is cancelled by SIGINT or SIGTERM
+func runServer(ctx context.Context, srv httpServer, port int) {
+	slog.Info("Starting server", "port", port)
+	if err := srv.StartWithContext(ctx); err != nil {
+		slog.Error("Failed to start server", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("Server stopped")
 }
 
 func init() {
@@ -80,6 +124,15 @@ func init() {
 
 	// Add flags for the serve command
 	serveCmd.Flags().Int("port", 8080, "Port to run the server on")
+	serveCmd.Flags().String("tls-cert-file", "", "PEM certificate to serve HTTPS with, reloaded on SIGHUP (requires --tls-key-file)")
+	serveCmd.Flags().String("tls-key-file", "", "PEM private key of --tls-cert-file")
+	serveCmd.Flags().String("tls-client-ca", "", "PEM CA bundle; when set, clients must present a certificate signed by it")
+	serveCmd.Flags().BoolVar(&startupBanner, "banner", false, "Print the startup summary to stdout")
+	serveCmd.Flags().Bool("event-replay", false, "Debug: serve POST /api/v1/events/replay to feed events to the event handler")
+	serveCmd.Flags().Bool("no-controller", false, "Serve only the HTTP API without starting the controller-runtime manager")
+	serveCmd.Flags().Int("max-concurrent-reconciles", 1, "Number of deployments reconciled in parallel")
+	serveCmd.Flags().StringSlice("reconcile-namespaces", nil, "Only reconcile deployments in these namespaces (comma-separated, default all)")
+	serveCmd.Flags().Bool("port-fallback", false, "Serve manager metrics and health probes on free ports when the default ports are taken")
 
 	// Add leader election flags
 	serveCmd.Flags().Bool("leader-elect", false, "Enable leader election for controller")
@@ -90,6 +143,27 @@ func init() {
 	if err := viper.BindPFlag("server.port", serveCmd.Flags().Lookup("port")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("server.event-replay", serveCmd.Flags().Lookup("event-replay")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("server.tls.cert-file", serveCmd.Flags().Lookup("tls-cert-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("server.tls.key-file", serveCmd.Flags().Lookup("tls-key-file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("server.tls.client-ca", serveCmd.Flags().Lookup("tls-client-ca")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("controller.max-concurrent-reconciles", serveCmd.Flags().Lookup("max-concurrent-reconciles")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("controller.reconcile-namespaces", serveCmd.Flags().Lookup("reconcile-namespaces")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("controller.port-fallback", serveCmd.Flags().Lookup("port-fallback")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("leader-election.enabled", serveCmd.Flags().Lookup("leader-elect")); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// shutdownSignals are the signals that trigger a graceful shutdown
+var shutdownSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// signalContext returns a context that is cancelled on the first SIGINT or SIGTERM.
+// Calling stop restores the default signal behaviour, so a second signal terminates
+// the process immediately if shutdown hangs.
+func signalContext() (ctx context.Context, stop context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), shutdownSignals...)
+}
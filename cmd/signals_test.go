@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestSignalContextCancelledOnSIGTERM(t *testing.T) {
+	ctx, stop := signalContext()
+	defer stop()
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatalf("failed to send SIGTERM: %v", err)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("context was not cancelled after SIGTERM")
+	}
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"k8s-controller/internal/infrastructure/config"
+)
+
+// startupBanner prints the startup summary to stdout in addition to the log line
+var startupBanner bool
+
+// startupSummary is the effective configuration reported once when serve or control starts
+type startupSummary struct {
+	Command         string
+	Port            int // 0 when the command serves no HTTP API
+	TLS             bool
+	Namespaces      []string
+	Resources       []string
+	MetricsAddress  string // empty when no metrics endpoint is served
+	HealthAddress   string // empty when no health endpoint is served
+	LeaderElection  bool
+	Webhook         bool
+	ImpersonateUser string
+	OTelEndpoint    string // empty when tracing is off
+	Mock            bool
+}
+
+// newStartupSummary fills the settings shared by serve and control from the configuration.
+// Settings only the controller-runtime manager honours are left for serve to fill in.
+func newStartupSummary(command string, cfg *config.Config) startupSummary {
+	namespaces := cfg.ResourceNamespaces
+	if cfg.DiscoverNamespaces {
+		namespaces = []string{"*"}
+	}
+
+	return startupSummary{
+		Command:         command,
+		Namespaces:      namespaces,
+		Resources:       cfg.WatchedResources,
+		ImpersonateUser: cfg.ImpersonateUser,
+		OTelEndpoint:    cfg.OTelEndpoint,
+		Mock:            mockEnabled(),
+	}
+}
+
+// attrs returns the summary as ordered key/value pairs
+func (s startupSummary) attrs() []any {
+	return []any{
+		"command", s.Command,
+		"port", s.Port,
+		"tls", s.TLS,
+		"namespaces", s.Namespaces,
+		"resources", s.Resources,
+		"metrics_address", s.MetricsAddress,
+		"health_address", s.HealthAddress,
+		"leader_election", s.LeaderElection,
+		"webhook", s.Webhook,
+		"impersonate_user", s.ImpersonateUser,
+		"otel_endpoint", s.OTelEndpoint,
+		"mock", s.Mock,
+	}
+}
+
+// logStartupSummary logs the summary as a single structured line and, with --banner, also
+// prints it to stdout
+func logStartupSummary(s startupSummary) {
+	slog.Info("Startup summary", s.attrs()...)
+	if startupBanner {
+		writeStartupBanner(os.Stdout, s)
+	}
+}
+
+// writeStartupBanner writes the summary as aligned "key: value" lines
+func writeStartupBanner(w io.Writer, s startupSummary) {
+	attrs := s.attrs()
+
+	width := 0
+	for i := 0; i < len(attrs); i += 2 {
+		width = max(width, len(attrs[i].(string)))
+	}
+
+	fmt.Fprintln(w, strings.Repeat("-", 40))
+	for i := 0; i < len(attrs); i += 2 {
+		fmt.Fprintf(w, "%-*s  %s\n", width+1, attrs[i].(string)+":", bannerValue(attrs[i+1]))
+	}
+	fmt.Fprintln(w, strings.Repeat("-", 40))
+}
+
+// bannerValue formats one summary value for the banner
+func bannerValue(v any) string {
+	switch v := v.(type) {
+	case []string:
+		if len(v) == 0 {
+			return "-"
+		}
+		return strings.Join(v, ",")
+	case string:
+		if v == "" {
+			return "-"
+		}
+		return v
+	case bool:
+		if v {
+			return "on"
+		}
+		return "off"
+	case int:
+		if v == 0 {
+			return "-"
+		}
+		return fmt.Sprint(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"k8s-controller/internal/infrastructure/config"
+)
+
+func TestNewStartupSummaryReportsDiscoveredNamespaces(t *testing.T) {
+	cfg := config.Default()
+	cfg.DiscoverNamespaces = true
+
+	summary := newStartupSummary("control", cfg)
+	if len(summary.Namespaces) != 1 || summary.Namespaces[0] != "*" {
+		t.Errorf("expected discovered namespaces to be reported as *, got %v", summary.Namespaces)
+	}
+}
+
+func TestWriteStartupBanner(t *testing.T) {
+	summary := startupSummary{
+		Command:        "serve",
+		Port:           8080,
+		Namespaces:     []string{"default", "staging"},
+		Resources:      []string{"deployments", "pods"},
+		MetricsAddress: ":8081",
+		LeaderElection: true,
+	}
+
+	var out bytes.Buffer
+	writeStartupBanner(&out, summary)
+
+	for _, want := range []string{
+		"port:              8080",
+		"namespaces:        default,staging",
+		"metrics_address:   :8081",
+		"health_address:    -",
+		"leader_election:   on",
+		"webhook:           off",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected banner to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s-controller/internal/domain"
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+var topSortBy string
+
+// podMetricsColumns defines the table columns for pod usage
+var podMetricsColumns = []column[domain.PodMetrics]{
+	{header: "NAME", width: 40, value: func(m domain.PodMetrics) string { return m.Name }},
+	{header: "NAMESPACE", width: 20, wide: true, value: func(m domain.PodMetrics) string { return m.Namespace }},
+	{header: "CPU(cores)", width: 12, value: func(m domain.PodMetrics) string { return fmt.Sprintf("%dm", m.CPUMilli) }},
+	{header: "MEMORY(Mi)", width: 14, value: func(m domain.PodMetrics) string { return fmt.Sprintf("%dMi", m.MemoryBytes/(1024*1024)) }},
+}
+
+// topCmd represents the top command
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Show resource usage",
+	Long:  `Show CPU and memory usage of Kubernetes resources, as reported by metrics-server`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		setupLogger()
+		return validateOutputFormat(outputFormat)
+	},
+}
+
+// topPodsCmd represents the top pods subcommand
+var topPodsCmd = &cobra.Command{
+	Use:   "pods",
+	Short: "Show pod resource usage",
+	Long:  `Show CPU and memory usage per pod in the specified namespace, highest usage first`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Validate the sort key before contacting the cluster
+		if err := domain.ValidatePodMetricsSortKey(topSortBy); err != nil {
+			slog.Error("Invalid sort key", "error", err)
+			os.Exit(1)
+		}
+
+		// Create Kubernetes client
+		client := kubernetes.NewClient(clientOptions()...)
+
+		// Connect to cluster
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := client.Connect(ctx); err != nil {
+			slog.Error("Failed to connect to Kubernetes cluster", "error", err)
+			os.Exit(1)
+		}
+
+		// List pod metrics
+		podMetrics, err := client.ListPodMetrics(ctx, namespace)
+		if errors.Is(err, kubernetes.ErrMetricsUnavailable) {
+			fmt.Println("Pod metrics are not available. Install metrics-server to use 'top': https://github.com/kubernetes-sigs/metrics-server")
+			os.Exit(1)
+		}
+		if err != nil {
+			slog.Error("Failed to list pod metrics", "error", err, "namespace", namespace)
+			os.Exit(1)
+		}
+
+		// Display results
+		if len(podMetrics) == 0 {
+			printInfo("No pod metrics found in namespace '%s'", namespace)
+			return
+		}
+
+		_ = domain.SortPodMetrics(podMetrics, topSortBy)
+		printTable(podMetricsColumns, podMetrics, outputFormat == "wide")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(topCmd)
+	topCmd.AddCommand(topPodsCmd)
+
+	topCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "default", "Kubernetes namespace")
+	topCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format (wide adds namespace)")
+	topPodsCmd.Flags().StringVar(&topSortBy, "sort-by", domain.SortByCPU, "Sort pods by cpu or memory usage")
+
+	// Complete namespace flag from the cluster when reachable
+	if err := topCmd.RegisterFlagCompletionFunc("namespace", completeNamespaces); err != nil {
+		panic(fmt.Errorf("failed to register namespace completion: %w", err))
+	}
+}
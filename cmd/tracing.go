@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"k8s-controller/internal/infrastructure/tracing"
+)
+
+// tracingFlushTimeout bounds how long shutdown waits for buffered spans to be exported
+const tracingFlushTimeout = 5 * time.Second
+
+// setupTracing exports spans to endpoint when it is set and returns a function that flushes
+// the remaining spans on shutdown
+func setupTracing(ctx context.Context, endpoint string) func() {
+	shutdown, err := tracing.Setup(ctx, endpoint)
+	if err != nil {
+		slog.Error("Failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+		defer cancel()
+
+		if err := shutdown(ctx); err != nil {
+			slog.Warn("Failed to flush traces", "error", err)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s-controller/internal/domain"
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+// treeCmd represents the tree command
+var treeCmd = &cobra.Command{
+	Use:   "tree",
+	Short: "Show the resources owned by a Kubernetes resource",
+	Long:  `Show a resource and the resources it owns, following owner references, as an indented tree`,
+}
+
+// treeDeploymentCmd represents the tree deployment subcommand
+var treeDeploymentCmd = &cobra.Command{
+	Use:     "deployment <name>",
+	Aliases: kubernetes.ResourceAliases("deployment"),
+	Short:   "Show a deployment's ReplicaSets and pods",
+	Long:    `Show a deployment, the ReplicaSets it controls (newest revision first) and the pods each of them controls`,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		// Create Kubernetes client
+		client := kubernetes.NewClient(clientOptions()...)
+
+		// Connect to cluster
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := client.Connect(ctx); err != nil {
+			slog.Error("Failed to connect to Kubernetes cluster", "error", err)
+			os.Exit(1)
+		}
+
+		// Get the deployment with its ReplicaSets and pods
+		tree, err := client.GetDeploymentTree(ctx, namespace, name)
+		if err != nil {
+			slog.Error("Failed to get deployment tree", "error", err, "name", name, "namespace", namespace)
+			os.Exit(1)
+		}
+
+		writeDeploymentTree(os.Stdout, tree)
+	},
+}
+
+// writeDeploymentTree writes the deployment, its ReplicaSets and their pods as an indented tree
+func writeDeploymentTree(w io.Writer, tree domain.DeploymentTree) {
+	deployment := tree.Deployment
+	fmt.Fprintf(w, "Deployment/%s  %d/%d ready\n", deployment.Name, deployment.Status.ReadyReplicas, deployment.Replicas)
+
+	for i, rs := range tree.ReplicaSets {
+		branch, indent := "├── ", "│   "
+		if i == len(tree.ReplicaSets)-1 {
+			branch, indent = "└── ", "    "
+		}
+		fmt.Fprintf(w, "%sReplicaSet/%s  revision %d, %d replicas\n", branch, rs.ReplicaSet, rs.Revision, rs.Replicas)
+
+		for j, pod := range rs.Pods {
+			podBranch := "├── "
+			if j == len(rs.Pods)-1 {
+				podBranch = "└── "
+			}
+			fmt.Fprintf(w, "%s%sPod/%s  %s, %d/%d ready, %d restarts\n", indent, podBranch, pod.Name, pod.Phase, pod.ReadyContainers, pod.TotalContainers, pod.Restarts)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(treeCmd)
+	treeCmd.AddCommand(treeDeploymentCmd)
+
+	// Add namespace flag to the tree command
+	treeCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "default", "Kubernetes namespace")
+
+	// Complete namespace flag from the cluster when reachable
+	if err := treeCmd.RegisterFlagCompletionFunc("namespace", completeNamespaces); err != nil {
+		panic(fmt.Errorf("failed to register namespace completion: %w", err))
+	}
+}
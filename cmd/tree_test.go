@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"k8s-controller/internal/domain"
+)
+
+func TestWriteDeploymentTree(t *testing.T) {
+	deployment := domain.Deployment{Name: "web", Replicas: 2}
+	deployment.Status.ReadyReplicas = 2
+	tree := domain.DeploymentTree{
+		Deployment: deployment,
+		ReplicaSets: []domain.ReplicaSetTree{
+			{
+				DeploymentRevision: domain.DeploymentRevision{Revision: 2, ReplicaSet: "web-7d9", Replicas: 2},
+				Pods: []domain.Pod{
+					{Name: "web-7d9-a", Phase: "Running", ReadyContainers: 1, TotalContainers: 1},
+					{Name: "web-7d9-b", Phase: "Running", ReadyContainers: 1, TotalContainers: 1, Restarts: 3},
+				},
+			},
+			{
+				DeploymentRevision: domain.DeploymentRevision{Revision: 1, ReplicaSet: "web-5c1"},
+				Pods:               []domain.Pod{{Name: "web-5c1-a", Phase: "Pending", TotalContainers: 1}},
+			},
+		},
+	}
+
+	var out strings.Builder
+	writeDeploymentTree(&out, tree)
+
+	want := `Deployment/web  2/2 ready
+├── ReplicaSet/web-7d9  revision 2, 2 replicas
+│   ├── Pod/web-7d9-a  Running, 1/1 ready, 0 restarts
+│   └── Pod/web-7d9-b  Running, 1/1 ready, 3 restarts
+└── ReplicaSet/web-5c1  revision 1, 0 replicas
+    └── Pod/web-5c1-a  Pending, 0/1 ready, 0 restarts
+`
+	if out.String() != want {
+		t.Errorf("unexpected tree:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
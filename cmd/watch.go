@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"k8s-controller/internal/domain"
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+var (
+	watchOutput    string
+	watchResources []string
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Stream resource changes",
+	Long:  `Stream changes to Kubernetes resources to stdout until interrupted`,
+}
+
+// watchEventsCmd represents the watch events subcommand
+var watchEventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Stream resource events as JSON",
+	Long: `Stream every resource event seen by the informers as one JSON object per line, until
+interrupted. Objects that already exist are reported as CREATED events when the watch starts.
+Logs are written to stderr, so stdout can be piped into tools such as jq.`,
+	Args:    cobra.NoArgs,
+	PreRunE: validateWatchEvents,
+	Run: func(cmd *cobra.Command, args []string) {
+		streamEvents(watchResources, newJSONEventPrinter(os.Stdout))
+	},
+}
+
+// watchDeploymentCmd represents the watch deployment subcommand
+var watchDeploymentCmd = &cobra.Command{
+	Use:     "deployment NAME",
+	Aliases: kubernetes.ResourceAliases("deployment"),
+	Short:   "Stream the events of a single deployment as JSON",
+	Long: `Stream the events of one deployment as one JSON object per line until interrupted,
+for example to follow a rollout. The deployment is reported as a CREATED event when the watch
+starts if it already exists.`,
+	Args:    cobra.ExactArgs(1),
+	PreRunE: validateWatchOutput,
+	Run: func(cmd *cobra.Command, args []string) {
+		streamEvents([]string{"deployments"}, newNamedResourceFilter("Deployment", args[0], newJSONEventPrinter(os.Stdout)))
+	},
+}
+
+// validateWatchOutput rejects output formats the watch commands can't print
+func validateWatchOutput(cmd *cobra.Command, args []string) error {
+	if watchOutput != "json" {
+		return fmt.Errorf("unsupported output format %q (supported: json)", watchOutput)
+	}
+	return nil
+}
+
+// validateWatchEvents rejects unsupported output formats and resource types before the watch
+// starts, so a typo in --resources fails instead of silently watching nothing
+func validateWatchEvents(cmd *cobra.Command, args []string) error {
+	if err := validateWatchOutput(cmd, args); err != nil {
+		return err
+	}
+	return kubernetes.ValidateResourceTypes(watchResources)
+}
+
+// streamEvents watches the resources in the namespace and passes every event to handler until
+// SIGINT or SIGTERM
+func streamEvents(resources []string, handler kubernetes.ResourceEventHandler) {
+	// Create Kubernetes client
+	client := kubernetes.NewClient(clientOptions()...)
+	client.SetNamespaces([]string{namespace})
+	client.SetWatchedResources(resources)
+	client.SetEventHandler(handler)
+
+	// Stream until SIGINT or SIGTERM
+	ctx, stop := signalContext()
+	defer stop()
+
+	connectCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if err := client.Connect(connectCtx); err != nil {
+		slog.Error("Failed to connect to Kubernetes cluster", "error", err)
+		os.Exit(1)
+	}
+
+	if err := client.WatchResources(ctx); err != nil {
+		slog.Error("Failed to watch resources", "error", err, "namespace", namespace)
+		os.Exit(1)
+	}
+
+	<-ctx.Done()
+	client.Stop()
+}
+
+// namedResourceFilter forwards only the events of one object to the wrapped handler
+type namedResourceFilter struct {
+	kind    string
+	name    string
+	handler kubernetes.ResourceEventHandler
+}
+
+// newNamedResourceFilter creates a handler that only forwards events of the object of the given
+// kind and name
+func newNamedResourceFilter(kind, name string, handler kubernetes.ResourceEventHandler) *namedResourceFilter {
+	return &namedResourceFilter{kind: kind, name: name, handler: handler}
+}
+
+// HandleEvent implements kubernetes.ResourceEventHandler
+func (f *namedResourceFilter) HandleEvent(ctx context.Context, event domain.ResourceEvent) error {
+	if event.Resource.Kind != f.kind || event.Resource.Name != f.name {
+		return nil
+	}
+	return f.handler.HandleEvent(ctx, event)
+}
+
+// watchEvent is the JSON form of a resource event written by watch events
+type watchEvent struct {
+	Time       time.Time              `json:"time"`
+	Type       string                 `json:"type"`
+	Kind       string                 `json:"kind"`
+	APIVersion string                 `json:"apiVersion,omitempty"`
+	Name       string                 `json:"name"`
+	Namespace  string                 `json:"namespace,omitempty"`
+	Labels     map[string]string      `json:"labels,omitempty"`
+	Data       map[string]interface{} `json:"data,omitempty"`
+}
+
+// jsonEventPrinter writes each resource event as one JSON line
+type jsonEventPrinter struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// newJSONEventPrinter creates an event handler that writes events to w
+func newJSONEventPrinter(w io.Writer) *jsonEventPrinter {
+	return &jsonEventPrinter{encoder: json.NewEncoder(w)}
+}
+
+// HandleEvent implements kubernetes.ResourceEventHandler
+func (p *jsonEventPrinter) HandleEvent(ctx context.Context, event domain.ResourceEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.encoder.Encode(watchEvent{
+		Time:       displayTime(time.Now()),
+		Type:       string(event.Type),
+		Kind:       event.Resource.Kind,
+		APIVersion: event.Resource.APIVersion,
+		Name:       event.Resource.Name,
+		Namespace:  event.Resource.Namespace,
+		Labels:     event.Resource.Labels,
+		Data:       event.Resource.Data,
+	})
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.AddCommand(watchEventsCmd)
+	watchCmd.AddCommand(watchDeploymentCmd)
+
+	watchEventsCmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Kubernetes namespace")
+	watchEventsCmd.Flags().StringVarP(&watchOutput, "output", "o", "json", "Output format (json)")
+	watchEventsCmd.Flags().StringSliceVar(&watchResources, "resources", []string{"deployments", "services", "pods"}, "Resources to watch (comma-separated)")
+
+	watchDeploymentCmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "Kubernetes namespace")
+	watchDeploymentCmd.Flags().StringVarP(&watchOutput, "output", "o", "json", "Output format (json)")
+
+	// Complete namespace flag from the cluster when reachable
+	for _, cmd := range []*cobra.Command{watchEventsCmd, watchDeploymentCmd} {
+		if err := cmd.RegisterFlagCompletionFunc("namespace", completeNamespaces); err != nil {
+			panic(fmt.Errorf("failed to register namespace completion: %w", err))
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"k8s-controller/internal/domain"
+)
+
+func TestJSONEventPrinterWritesOneLinePerEvent(t *testing.T) {
+	var out bytes.Buffer
+	printer := newJSONEventPrinter(&out)
+
+	events := []domain.ResourceEvent{
+		{Type: domain.ResourceEventCreated, Resource: domain.Resource{Kind: "Deployment", Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		{Type: domain.ResourceEventDeleted, Resource: domain.Resource{Kind: "Service", Name: "web", Namespace: "default"}},
+	}
+	for _, event := range events {
+		if err := printer.HandleEvent(context.Background(), event); err != nil {
+			t.Fatalf("HandleEvent failed: %v", err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(events) {
+		t.Fatalf("expected %d lines, got %q", len(events), out.String())
+	}
+	for i, line := range lines {
+		var got watchEvent
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line %d is not JSON: %v", i, err)
+		}
+		want := events[i]
+		if got.Type != string(want.Type) || got.Kind != want.Resource.Kind || got.Name != want.Resource.Name {
+			t.Errorf("line %d: got %+v, want %+v", i, got, want)
+		}
+		if got.Time.IsZero() {
+			t.Errorf("line %d: expected a timestamp", i)
+		}
+	}
+}
+
+func TestNamedResourceFilterForwardsOnlyMatchingObject(t *testing.T) {
+	var out bytes.Buffer
+	filter := newNamedResourceFilter("Deployment", "web", newJSONEventPrinter(&out))
+
+	events := []domain.ResourceEvent{
+		{Type: domain.ResourceEventUpdated, Resource: domain.Resource{Kind: "Deployment", Name: "web", Namespace: "default"}},
+		{Type: domain.ResourceEventUpdated, Resource: domain.Resource{Kind: "Deployment", Name: "api", Namespace: "default"}},
+		{Type: domain.ResourceEventUpdated, Resource: domain.Resource{Kind: "Service", Name: "web", Namespace: "default"}},
+	}
+	for _, event := range events {
+		if err := filter.HandleEvent(context.Background(), event); err != nil {
+			t.Fatalf("HandleEvent failed: %v", err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 1 || !strings.Contains(lines[0], `"kind":"Deployment"`) || !strings.Contains(lines[0], `"name":"web"`) {
+		t.Errorf("expected only the web deployment event, got %q", out.String())
+	}
+}
+
+func TestValidateWatchEventsRejectsUnknownResources(t *testing.T) {
+	output, resources := watchOutput, watchResources
+	t.Cleanup(func() { watchOutput, watchResources = output, resources })
+	watchOutput = "json"
+
+	watchResources = []string{"deploy", "svc", "pods"}
+	if err := validateWatchEvents(watchEventsCmd, nil); err != nil {
+		t.Errorf("expected supported resources to pass, got %v", err)
+	}
+
+	watchResources = []string{"deployments", "deploymnets"}
+	err := validateWatchEvents(watchEventsCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "deploymnets") {
+		t.Errorf("expected an error naming the unknown resource, got %v", err)
+	}
+}
@@ -4,12 +4,18 @@ package app
 import (
 	"context"
 	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"k8s-controller/internal/app/handlers"
 	"k8s-controller/internal/domain"
 	"k8s-controller/internal/infrastructure/config"
 	"k8s-controller/internal/infrastructure/kubernetes"
+	"k8s-controller/internal/infrastructure/metrics"
 )
 
 // KubernetesController is responsible for watching and reacting to Kubernetes resources
@@ -20,10 +26,15 @@ type KubernetesController struct {
 	ctx             context.Context
 	cancelFunc      context.CancelFunc
 	config          *config.Config
+	// metricsServer serves /metrics while the controller runs, nil when disabled
+	metricsServer *http.Server
+	// wg tracks the goroutines started by Start so Stop can wait for them
+	wg sync.WaitGroup
 }
 
-// NewKubernetesController creates a new controller instance
-func NewKubernetesController(cfg *config.Config) *KubernetesController {
+// NewKubernetesController creates a new controller instance whose Kubernetes client is
+// configured with opts
+func NewKubernetesController(cfg *config.Config, opts ...kubernetes.ClientOption) *KubernetesController {
 	// Create context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -33,16 +44,45 @@ func NewKubernetesController(cfg *config.Config) *KubernetesController {
 	}
 
 	// Create client
-	client := kubernetes.NewClient()
+	client := kubernetes.NewClient(opts...)
+	client.SetResyncPeriods(cfg.ResyncPeriod, cfg.ResyncPeriods)
+	client.SetNamespaces(cfg.ResourceNamespaces)
+	client.SetWatchedResources(cfg.WatchedResources)
+	client.SetDiscoverNamespaces(cfg.DiscoverNamespaces)
+	client.SetInformerScope(cfg.ClusterScopeThreshold, cfg.PerNamespaceInformers)
+	client.SetIndexLabels(cfg.IndexLabels)
+	client.SetWatchSelector(cfg.WatchSelector)
+	client.SetResourceSelectors(cfg.WatchSelectors)
+	client.SetListCacheTTL(cfg.ListCacheTTL)
+	client.SetMaxEventRetries(cfg.MaxEventRetries)
+	client.SetEventBuffer(cfg.EventBufferSize, cfg.EventBufferPolicy == config.EventBufferDrop)
+	client.SetImpersonation(cfg.ImpersonateUser, cfg.ImpersonateGroups)
+	client.SetApplyAttempts(cfg.ApplyAttempts)
+	client.SetConnection(kubernetes.ConnectionOptions{
+		Kubeconfig: cfg.KubeconfigPath,
+		Context:    cfg.KubeContext,
+		QPS:        cfg.KubeQPS,
+		Burst:      cfg.KubeBurst,
+	})
 
 	// Create domain services
 	resourceService := domain.NewResourceService(client)
+	resourceService.OnReport(metrics.RecordDeploymentReport)
+	metrics.SetEventNamespaceLabel(cfg.MetricsNamespaceLabel)
 
 	// Create handlers
 	resourceHandler := handlers.NewResourceHandler(resourceService)
 
-	// Set handler in client
-	client.SetEventHandler(resourceHandler)
+	// Optionally record processed events in a config map audit log
+	var handler kubernetes.ResourceEventHandler = resourceHandler
+	if cfg.AuditEnabled {
+		auditNamespace, auditName := splitNamespacedName(cfg.AuditConfigMap)
+		handler = kubernetes.NewMultiHandler(resourceHandler,
+			kubernetes.NewAuditHandler(client, auditNamespace, auditName, cfg.AuditMaxEntries))
+	}
+
+	// Set handler in client, forwarding only the configured event types
+	client.SetEventHandler(kubernetes.NewEventTypeFilter(handler, cfg.EventTypes...))
 
 	return &KubernetesController{
 		client:          client,
@@ -54,6 +94,14 @@ func NewKubernetesController(cfg *config.Config) *KubernetesController {
 	}
 }
 
+// splitNamespacedName splits "namespace/name", defaulting the namespace to "default"
+func splitNamespacedName(value string) (string, string) {
+	if namespace, name, ok := strings.Cut(value, "/"); ok {
+		return namespace, name
+	}
+	return "default", value
+}
+
 // Start initializes and starts the controller
 func (c *KubernetesController) Start() error {
 	slog.Info("Starting Kubernetes controller")
@@ -64,8 +112,38 @@ func (c *KubernetesController) Start() error {
 		return err
 	}
 
+	// Fail early with precise RBAC errors instead of failing later in the watch loop
+	if c.config.CheckPermissions {
+		namespaces := c.config.ResourceNamespaces
+		resources := c.client.WatchStatus().Resources
+		if c.config.DiscoverNamespaces {
+			namespaces = []string{metav1.NamespaceAll}
+			resources = append([]string{"namespaces"}, resources...)
+		} else if c.client.WatchStatus().ClusterScoped {
+			// A cluster-scoped informer lists and watches across all namespaces
+			namespaces = []string{metav1.NamespaceAll}
+		}
+		if err := c.client.CheckPermissions(c.ctx, namespaces, resources); err != nil {
+			slog.Error("Permission check failed", "error", err)
+			return err
+		}
+	}
+
+	// Serve the event and report metrics; control runs without the manager's metrics server
+	if c.config.MetricsBindAddress != "" {
+		srv, err := metrics.Serve(c.config.MetricsBindAddress)
+		if err != nil {
+			slog.Error("Failed to start metrics server", "error", err)
+			return err
+		}
+		c.metricsServer = srv
+		slog.Info("Serving metrics", "address", srv.Addr)
+	}
+
 	// Start watching resources in a goroutine
+	c.wg.Add(2)
 	go func() {
+		defer c.wg.Done()
 		if err := c.resourceService.WatchResources(c.ctx); err != nil {
 			if c.ctx.Err() == nil { // Only log if not due to context cancellation
 				slog.Error("Error watching resources", "error", err)
@@ -74,15 +152,61 @@ func (c *KubernetesController) Start() error {
 	}()
 
 	// Start a periodic health check
-	go c.startPeriodicHealthCheck()
+	go func() {
+		defer c.wg.Done()
+		c.startPeriodicHealthCheck()
+	}()
+
+	// Start the periodic deployment health and policy report
+	if c.config.ReportInterval > 0 {
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			if err := c.resourceService.RunPeriodicReport(c.ctx, c.config.ReportInterval); err != nil {
+				slog.Error("Periodic report stopped", "error", err)
+			}
+		}()
+	}
 
 	return nil
 }
 
-// Stop gracefully stops the controller
+// Stop gracefully stops the controller and waits up to the configured shutdown timeout for the
+// goroutines started by Start to exit and the queued events to be handled. A timeout of 0
+// waits until they are done.
 func (c *KubernetesController) Stop() {
 	slog.Info("Stopping Kubernetes controller")
 	c.cancelFunc()
+	c.client.Stop()
+
+	if c.metricsServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.ShutdownTimeout)
+		if err := c.metricsServer.Shutdown(ctx); err != nil {
+			slog.Warn("Failed to stop metrics server", "error", err)
+		}
+		cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		// WatchResources has returned, so the event worker it started is known
+		<-c.client.EventsDrained()
+		close(done)
+	}()
+
+	if c.config.ShutdownTimeout <= 0 {
+		<-done
+		slog.Info("Kubernetes controller stopped")
+		return
+	}
+
+	select {
+	case <-done:
+		slog.Info("Kubernetes controller stopped")
+	case <-time.After(c.config.ShutdownTimeout):
+		slog.Warn("Timed out waiting for controller goroutines to exit", "timeout", c.config.ShutdownTimeout)
+	}
 }
 
 // startPeriodicHealthCheck runs a periodic health check
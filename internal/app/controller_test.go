@@ -0,0 +1,46 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"k8s-controller/internal/infrastructure/config"
+)
+
+func TestNewKubernetesControllerWatchesConfiguredResources(t *testing.T) {
+	cfg := config.Default()
+	cfg.WatchedResources = []string{"deploy", "configmaps"}
+
+	controller := NewKubernetesController(cfg)
+	defer controller.cancelFunc()
+
+	want := []string{"deployments", "configmaps"}
+	if got := controller.client.WatchStatus().Resources; !slices.Equal(got, want) {
+		t.Errorf("expected the client to watch %v, got %v", want, got)
+	}
+}
+
+func TestNewKubernetesControllerWatchesResourcesFile(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	resourcesFile := filepath.Join(t.TempDir(), "resources.txt")
+	if err := os.WriteFile(resourcesFile, []byte("# batch workloads\njobs\ncj\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("kubernetes.resources-file", resourcesFile)
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	controller := NewKubernetesController(cfg)
+	defer controller.cancelFunc()
+
+	want := []string{"jobs", "cronjobs"}
+	if got := controller.client.WatchStatus().Resources; !slices.Equal(got, want) {
+		t.Errorf("expected the client to watch the resources file %v, got %v", want, got)
+	}
+}
@@ -3,8 +3,13 @@ package handlers
 import (
 	"context"
 	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 
 	"k8s-controller/internal/domain"
+	"k8s-controller/internal/infrastructure/metrics"
+	"k8s-controller/internal/infrastructure/tracing"
 )
 
 // ResourceHandler processes resource events based on business rules
@@ -27,6 +32,14 @@ func (h *ResourceHandler) HandleEvent(ctx context.Context, event domain.Resource
 		"name", event.Resource.Name,
 		"namespace", event.Resource.Namespace)
 
+	ctx, span := tracing.Start(ctx, "HandleResourceEvent", event.Resource.Kind, event.Resource.Namespace, event.Resource.Name)
+	span.SetAttributes(attribute.String("k8s_controller.event.type", string(event.Type)))
+
 	// Forward event to the domain service
-	return h.resourceService.HandleResourceEvent(ctx, event)
+	start := time.Now()
+	err := h.resourceService.HandleResourceEvent(ctx, event)
+	metrics.RecordEventProcessed(event, time.Since(start))
+
+	tracing.End(span, err)
+	return err
 }
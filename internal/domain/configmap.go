@@ -0,0 +1,16 @@
+package domain
+
+// ConfigMap represents a Kubernetes config map
+type ConfigMap struct {
+	Name      string
+	Namespace string
+	DataKeys  []string
+	Data      map[string]string `json:",omitempty"`
+	Labels    map[string]string
+}
+
+// WithoutValues returns a copy of the config map with data values removed, keeping only the keys
+func (c ConfigMap) WithoutValues() ConfigMap {
+	c.Data = nil
+	return c
+}
@@ -2,24 +2,82 @@ package domain
 
 import "time"
 
+// DeploymentCondition describes the state of a deployment at a point in time
+type DeploymentCondition struct {
+	Type               string
+	Status             string
+	Reason             string
+	Message            string
+	LastTransitionTime time.Time
+}
+
 // DeploymentStatus contains status information for a deployment
 type DeploymentStatus struct {
 	ReadyReplicas       int32
 	UpdatedReplicas     int32
 	AvailableReplicas   int32
 	UnavailableReplicas int32
+	Conditions          []DeploymentCondition
+}
+
+// GetCondition returns the condition of the given type, if present
+func (s DeploymentStatus) GetCondition(conditionType string) (DeploymentCondition, bool) {
+	for _, condition := range s.Conditions {
+		if condition.Type == conditionType {
+			return condition, true
+		}
+	}
+	return DeploymentCondition{}, false
+}
+
+// IsStuck reports whether the rollout has exceeded its progress deadline
+func (s DeploymentStatus) IsStuck() bool {
+	condition, ok := s.GetCondition("Progressing")
+	return ok && condition.Status == "False" && condition.Reason == "ProgressDeadlineExceeded"
 }
 
 // Deployment represents a Kubernetes deployment
 type Deployment struct {
-	Name              string
-	Namespace         string
-	ReadyReplicas     int32
-	UpdatedReplicas   int32
-	AvailableReplicas int32
-	Replicas          int32
-	Labels            map[string]string
-	CreationTimestamp string
-	Status            DeploymentStatus
-	CreatedAt         time.Time
+	Name               string
+	Namespace          string
+	ReadyReplicas      int32
+	UpdatedReplicas    int32
+	AvailableReplicas  int32
+	Replicas           int32
+	Labels             map[string]string
+	Annotations        map[string]string
+	Images             []string
+	CreationTimestamp  string // RFC3339 in UTC
+	Status             DeploymentStatus
+	CreatedAt          time.Time
+	Generation         int64
+	ObservedGeneration int64
+}
+
+// IsStatusStale reports whether the status has not yet caught up with the latest spec
+func (d Deployment) IsStatusStale() bool {
+	return d.ObservedGeneration != d.Generation
+}
+
+// DeploymentRevision is one entry of a deployment's rollout history, backed by a ReplicaSet
+type DeploymentRevision struct {
+	Revision    int64
+	ReplicaSet  string
+	ChangeCause string
+	Images      []string
+	Replicas    int32
+	CreatedAt   time.Time
+}
+
+// DeploymentTree is a deployment with the ReplicaSets it controls, newest revision first, and the
+// pods each of them controls
+type DeploymentTree struct {
+	Deployment  Deployment
+	ReplicaSets []ReplicaSetTree
+}
+
+// ReplicaSetTree is a revision of a deployment with the pods its ReplicaSet controls, sorted by name
+type ReplicaSetTree struct {
+	DeploymentRevision
+	Pods []Pod
 }
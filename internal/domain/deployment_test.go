@@ -0,0 +1,33 @@
+package domain
+
+import "testing"
+
+func TestDeploymentStatusIsStuck(t *testing.T) {
+	progressing := DeploymentStatus{Conditions: []DeploymentCondition{
+		{Type: "Available", Status: "True", Reason: "MinimumReplicasAvailable"},
+		{Type: "Progressing", Status: "True", Reason: "NewReplicaSetAvailable"},
+	}}
+	if progressing.IsStuck() {
+		t.Error("expected progressing deployment not to be stuck")
+	}
+
+	stuck := DeploymentStatus{Conditions: []DeploymentCondition{
+		{Type: "Progressing", Status: "False", Reason: "ProgressDeadlineExceeded"},
+	}}
+	if !stuck.IsStuck() {
+		t.Error("expected deployment past its progress deadline to be stuck")
+	}
+
+	if (DeploymentStatus{}).IsStuck() {
+		t.Error("expected deployment without conditions not to be stuck")
+	}
+}
+
+func TestDeploymentIsStatusStale(t *testing.T) {
+	if (Deployment{Generation: 3, ObservedGeneration: 3}).IsStatusStale() {
+		t.Error("expected status observing the latest generation not to be stale")
+	}
+	if !(Deployment{Generation: 4, ObservedGeneration: 3}).IsStatusStale() {
+		t.Error("expected status behind the latest generation to be stale")
+	}
+}
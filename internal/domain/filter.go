@@ -0,0 +1,45 @@
+package domain
+
+import "fmt"
+
+// Supported deployment status filters
+const (
+	StatusFilterAll     = "all"
+	StatusFilterReady   = "ready"
+	StatusFilterUnready = "unready"
+)
+
+// ValidateDeploymentStatusFilter checks that the status filter is supported. An empty filter means all.
+func ValidateDeploymentStatusFilter(filter string) error {
+	switch filter {
+	case "", StatusFilterAll, StatusFilterReady, StatusFilterUnready:
+		return nil
+	default:
+		return fmt.Errorf("unknown status filter %q (supported: %s, %s, %s)", filter, StatusFilterAll, StatusFilterReady, StatusFilterUnready)
+	}
+}
+
+// IsReady reports whether every desired replica is ready
+func (d Deployment) IsReady() bool {
+	return d.ReadyReplicas == d.Replicas
+}
+
+// FilterDeploymentsByStatus returns the deployments matching the status filter.
+// A deployment is ready when its ready replicas equal its desired replicas.
+func FilterDeploymentsByStatus(deployments []Deployment, filter string) ([]Deployment, error) {
+	if err := ValidateDeploymentStatusFilter(filter); err != nil {
+		return nil, err
+	}
+	if filter == "" || filter == StatusFilterAll {
+		return deployments, nil
+	}
+
+	wantReady := filter == StatusFilterReady
+	filtered := make([]Deployment, 0, len(deployments))
+	for _, deployment := range deployments {
+		if deployment.IsReady() == wantReady {
+			filtered = append(filtered, deployment)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "testing"
+
+func TestFilterDeploymentsByStatus(t *testing.T) {
+	deployments := []Deployment{
+		{Name: "ready", Replicas: 2, ReadyReplicas: 2},
+		{Name: "degraded", Replicas: 3, ReadyReplicas: 1},
+		{Name: "scaled-down", Replicas: 0, ReadyReplicas: 0},
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"", []string{"ready", "degraded", "scaled-down"}},
+		{StatusFilterAll, []string{"ready", "degraded", "scaled-down"}},
+		{StatusFilterReady, []string{"ready", "scaled-down"}},
+		{StatusFilterUnready, []string{"degraded"}},
+	}
+
+	for _, tt := range tests {
+		filtered, err := FilterDeploymentsByStatus(deployments, tt.filter)
+		if err != nil {
+			t.Fatalf("filter %q: unexpected error: %v", tt.filter, err)
+		}
+		if len(filtered) != len(tt.want) {
+			t.Fatalf("filter %q: expected %v, got %+v", tt.filter, tt.want, filtered)
+		}
+		for i, name := range tt.want {
+			if filtered[i].Name != name {
+				t.Errorf("filter %q: expected %s at %d, got %s", tt.filter, name, i, filtered[i].Name)
+			}
+		}
+	}
+
+	if _, err := FilterDeploymentsByStatus(deployments, "healthy"); err == nil {
+		t.Error("expected an error for an unknown filter")
+	}
+}
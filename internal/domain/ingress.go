@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// IngressPath represents a single routing rule of an ingress
+type IngressPath struct {
+	Host        string
+	Path        string
+	ServiceName string
+	ServicePort string
+}
+
+// Ingress represents a Kubernetes ingress
+type Ingress struct {
+	Name      string
+	Namespace string
+	ClassName string
+	Hosts     []string
+	Paths     []IngressPath
+	TLSHosts  []string
+	Labels    map[string]string
+	CreatedAt time.Time
+}
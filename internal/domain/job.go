@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// Job represents a Kubernetes batch job
+type Job struct {
+	Name           string
+	Namespace      string
+	Completions    int32
+	Parallelism    int32
+	Active         int32
+	Succeeded      int32
+	Failed         int32
+	StartTime      *time.Time
+	CompletionTime *time.Time
+	Labels         map[string]string
+	CreatedAt      time.Time
+}
+
+// CronJob represents a Kubernetes cron job
+type CronJob struct {
+	Name               string
+	Namespace          string
+	Schedule           string
+	Suspend            bool
+	Active             int32
+	LastScheduleTime   *time.Time
+	LastSuccessfulTime *time.Time
+	Labels             map[string]string
+	CreatedAt          time.Time
+}
@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"fmt"
+	"strings"
+)
+
 // ResourceEventType represents the type of resource event
 type ResourceEventType string
 
@@ -14,14 +19,47 @@ const (
 	ResourceEventDeleted ResourceEventType = "DELETED"
 )
 
+// ParseEventType returns the event type named by s, ignoring case and surrounding spaces.
+// Anything but CREATED, UPDATED and DELETED is rejected.
+func ParseEventType(s string) (ResourceEventType, error) {
+	eventType := ResourceEventType(strings.ToUpper(strings.TrimSpace(s)))
+	switch eventType {
+	case ResourceEventCreated, ResourceEventUpdated, ResourceEventDeleted:
+		return eventType, nil
+	default:
+		return "", fmt.Errorf("unknown event type %q (expected CREATED, UPDATED or DELETED)", s)
+	}
+}
+
 // Resource represents a Kubernetes resource
 type Resource struct {
-	Kind       string
-	Name       string
-	Namespace  string
-	APIVersion string
-	Labels     map[string]string
-	Data       map[string]interface{}
+	Kind            string
+	Name            string
+	Namespace       string
+	APIVersion      string
+	Labels          map[string]string
+	Data            map[string]interface{}
+	OwnerReferences []OwnerRef
+}
+
+// OwnerRef identifies an object that owns a resource, e.g. the replica set owning a pod.
+// Owners are always in the namespace of the resource they own.
+type OwnerRef struct {
+	Kind string
+	Name string
+	UID  string
+	// Controller marks the managing owner; a resource has at most one
+	Controller bool
+}
+
+// ControllerOwner returns the owner that manages the resource, if any
+func (r Resource) ControllerOwner() (OwnerRef, bool) {
+	for _, owner := range r.OwnerReferences {
+		if owner.Controller {
+			return owner, true
+		}
+	}
+	return OwnerRef{}, false
 }
 
 // ResourceEvent represents an event that occurred on a Kubernetes resource
@@ -0,0 +1,23 @@
+package domain
+
+import "testing"
+
+func TestParseEventType(t *testing.T) {
+	for input, want := range map[string]ResourceEventType{
+		"CREATED":   ResourceEventCreated,
+		"updated":   ResourceEventUpdated,
+		" Deleted ": ResourceEventDeleted,
+	} {
+		got, err := ParseEventType(input)
+		if err != nil || got != want {
+			t.Errorf("ParseEventType(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	// Watch event names from the Kubernetes API are not resource event types
+	for _, input := range []string{"ADDED", "MODIFIED", "PATCHED", ""} {
+		if _, err := ParseEventType(input); err == nil {
+			t.Errorf("expected ParseEventType(%q) to fail", input)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// Pod represents a Kubernetes pod
+type Pod struct {
+	Name            string
+	Namespace       string
+	Phase           string
+	ReadyContainers int32
+	TotalContainers int32
+	Restarts        int32
+	NodeName        string
+	Labels          map[string]string
+	CreatedAt       time.Time
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Supported pod metrics sort keys
+const (
+	SortByCPU    = "cpu"
+	SortByMemory = "memory"
+)
+
+// PodMetrics holds the current resource usage of a pod, summed over its containers
+type PodMetrics struct {
+	Name        string
+	Namespace   string
+	CPUMilli    int64
+	MemoryBytes int64
+}
+
+// ValidatePodMetricsSortKey checks that the sort key is supported
+func ValidatePodMetricsSortKey(key string) error {
+	switch key {
+	case SortByCPU, SortByMemory:
+		return nil
+	default:
+		return fmt.Errorf("unknown sort key %q (supported: %s, %s)", key, SortByCPU, SortByMemory)
+	}
+}
+
+// SortPodMetrics sorts pod metrics in place by usage, highest first.
+// Ties are broken by the other resource and then by name.
+func SortPodMetrics(metrics []PodMetrics, key string) error {
+	if err := ValidatePodMetricsSortKey(key); err != nil {
+		return err
+	}
+
+	sort.SliceStable(metrics, func(i, j int) bool {
+		a, b := metrics[i], metrics[j]
+		primaryA, primaryB := a.CPUMilli, b.CPUMilli
+		secondaryA, secondaryB := a.MemoryBytes, b.MemoryBytes
+		if key == SortByMemory {
+			primaryA, primaryB, secondaryA, secondaryB = secondaryA, secondaryB, primaryA, primaryB
+		}
+
+		if primaryA != primaryB {
+			return primaryA > primaryB
+		}
+		if secondaryA != secondaryB {
+			return secondaryA > secondaryB
+		}
+		return a.Name < b.Name
+	})
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "testing"
+
+func TestSortPodMetrics(t *testing.T) {
+	metrics := []PodMetrics{
+		{Name: "a", CPUMilli: 10, MemoryBytes: 300},
+		{Name: "b", CPUMilli: 50, MemoryBytes: 100},
+		{Name: "c", CPUMilli: 10, MemoryBytes: 200},
+	}
+
+	if err := SortPodMetrics(metrics, SortByCPU); err != nil {
+		t.Fatalf("SortPodMetrics failed: %v", err)
+	}
+	assertPodOrder(t, metrics, "b", "a", "c")
+
+	if err := SortPodMetrics(metrics, SortByMemory); err != nil {
+		t.Fatalf("SortPodMetrics failed: %v", err)
+	}
+	assertPodOrder(t, metrics, "a", "c", "b")
+
+	if err := SortPodMetrics(metrics, "disk"); err == nil {
+		t.Error("expected an error for an unknown sort key")
+	}
+}
+
+func assertPodOrder(t *testing.T, metrics []PodMetrics, names ...string) {
+	t.Helper()
+	for i, name := range names {
+		if metrics[i].Name != name {
+			t.Errorf("expected %s at position %d, got %s", name, i, metrics[i].Name)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// MinReplicasAnnotation sets the replica floor of a deployment. When spec.replicas drops
+// below the annotated value the deployment reconciler scales it back up.
+const MinReplicasAnnotation = "k8s-controller/min-replicas"
+
+// Policies checked by CheckDeploymentPolicies
+const (
+	PolicyReplicaFloor = "replica-floor"
+	PolicyStuckRollout = "stuck-rollout"
+)
+
+// PolicyViolation describes a deployment that breaks a policy
+type PolicyViolation struct {
+	Policy    string
+	Name      string
+	Namespace string
+	Message   string
+}
+
+// ParseReplicaFloor parses a MinReplicasAnnotation value, which must be a non-negative integer
+func ParseReplicaFloor(value string) (int32, error) {
+	floor, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid replica floor %q: %w", value, err)
+	}
+	if floor < 0 {
+		return 0, fmt.Errorf("invalid replica floor %q: must not be negative", value)
+	}
+	return int32(floor), nil
+}
+
+// CheckDeploymentPolicies returns the policy violations of the deployments: replicas below
+// the annotated floor and rollouts past their progress deadline. Invalid floors are skipped.
+func CheckDeploymentPolicies(deployments []Deployment) []PolicyViolation {
+	violations := []PolicyViolation{}
+	for _, deployment := range deployments {
+		if value, ok := deployment.Annotations[MinReplicasAnnotation]; ok {
+			if floor, err := ParseReplicaFloor(value); err == nil && deployment.Replicas < floor {
+				violations = append(violations, PolicyViolation{
+					Policy:    PolicyReplicaFloor,
+					Name:      deployment.Name,
+					Namespace: deployment.Namespace,
+					Message:   fmt.Sprintf("%d replicas is below the floor of %d", deployment.Replicas, floor),
+				})
+			}
+		}
+		if deployment.Status.IsStuck() {
+			condition, _ := deployment.Status.GetCondition("Progressing")
+			violations = append(violations, PolicyViolation{
+				Policy:    PolicyStuckRollout,
+				Name:      deployment.Name,
+				Namespace: deployment.Namespace,
+				Message:   condition.Message,
+			})
+		}
+	}
+	return violations
+}
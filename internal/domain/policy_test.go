@@ -0,0 +1,36 @@
+package domain
+
+import "testing"
+
+func TestParseReplicaFloor(t *testing.T) {
+	if floor, err := ParseReplicaFloor("3"); err != nil || floor != 3 {
+		t.Errorf("ParseReplicaFloor(3) = %d, %v", floor, err)
+	}
+	for _, value := range []string{"", "many", "-1", "1.5"} {
+		if _, err := ParseReplicaFloor(value); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+}
+
+func TestCheckDeploymentPolicies(t *testing.T) {
+	stuck := DeploymentStatus{Conditions: []DeploymentCondition{
+		{Type: "Progressing", Status: "False", Reason: "ProgressDeadlineExceeded", Message: "timed out"},
+	}}
+	violations := CheckDeploymentPolicies([]Deployment{
+		{Name: "ok", Replicas: 3, Annotations: map[string]string{MinReplicasAnnotation: "2"}},
+		{Name: "low", Replicas: 1, Annotations: map[string]string{MinReplicasAnnotation: "2"}},
+		{Name: "invalid", Replicas: 1, Annotations: map[string]string{MinReplicasAnnotation: "many"}},
+		{Name: "stuck", Replicas: 1, Status: stuck},
+	})
+
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 violations, got %+v", violations)
+	}
+	if violations[0].Policy != PolicyReplicaFloor || violations[0].Name != "low" {
+		t.Errorf("unexpected first violation %+v", violations[0])
+	}
+	if violations[1].Policy != PolicyStuckRollout || violations[1].Name != "stuck" || violations[1].Message != "timed out" {
+		t.Errorf("unexpected second violation %+v", violations[1])
+	}
+}
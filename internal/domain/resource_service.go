@@ -2,7 +2,10 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 )
 
 // ResourceClient defines the interface for interacting with Kubernetes resources
@@ -11,6 +14,8 @@ type ResourceClient interface {
 	WatchResources(ctx context.Context) error
 	GetResource(ctx context.Context, kind, name, namespace string) (Resource, error)
 	ApplyResource(ctx context.Context, resource Resource) error
+	// ListWatchedDeployments lists the deployments of every watched namespace from the informer cache
+	ListWatchedDeployments(ctx context.Context) ([]Deployment, error)
 }
 
 // ResourceService is the domain service for handling Kubernetes resource operations
@@ -18,17 +23,29 @@ type ResourceService interface {
 	WatchResources(ctx context.Context) error
 	HandleResourceEvent(ctx context.Context, event ResourceEvent) error
 	ProcessDeployment(ctx context.Context, deployment Deployment) error
+	RunPeriodicReport(ctx context.Context, interval time.Duration) error
+	OnReport(observer func(DeploymentReport))
+	RegisterKindHandler(kind string, handler KindHandler)
 }
 
+// KindHandler holds the business logic for resource events of one kind. An error is returned
+// from HandleResourceEvent, so the event is retried.
+type KindHandler func(ctx context.Context, event ResourceEvent) error
+
 // resourceService implements the ResourceService interface
 type resourceService struct {
 	client ResourceClient
+	// reportObservers are called with every periodic report, e.g. to export metrics
+	reportObservers []func(DeploymentReport)
+	// kindHandlers handle the events of each resource kind; other kinds are only logged
+	kindHandlers map[string]KindHandler
 }
 
 // NewResourceService creates a new resource service
 func NewResourceService(client ResourceClient) ResourceService {
 	return &resourceService{
-		client: client,
+		client:       client,
+		kindHandlers: make(map[string]KindHandler),
 	}
 }
 
@@ -45,13 +62,31 @@ func (s *resourceService) ProcessDeployment(ctx context.Context, deployment Depl
 		"namespace", deployment.Namespace,
 		"replicas", deployment.Replicas)
 
+	// Status that hasn't observed the latest spec can't tell us about the current rollout
+	if deployment.IsStatusStale() {
+		slog.Debug("Deployment status is stale, waiting for controller to observe latest generation",
+			"name", deployment.Name,
+			"namespace", deployment.Namespace,
+			"generation", deployment.Generation,
+			"observedGeneration", deployment.ObservedGeneration)
+	} else if deployment.Status.IsStuck() {
+		// A rollout past its progress deadline is stuck rather than still progressing
+		condition, _ := deployment.Status.GetCondition("Progressing")
+		slog.Warn("Deployment rollout is stuck",
+			"name", deployment.Name,
+			"namespace", deployment.Namespace,
+			"reason", condition.Reason,
+			"message", condition.Message)
+	}
+
 	// Add your business logic for processing deployments here
 	// For example, you could validate the deployment, update related resources, etc.
 
 	return nil
 }
 
-// HandleResourceEvent processes a resource event
+// HandleResourceEvent processes a resource event by passing it to the handler registered for its
+// kind. Events of kinds without a handler are logged.
 func (s *resourceService) HandleResourceEvent(ctx context.Context, event ResourceEvent) error {
 	slog.Info("Handling resource event",
 		"kind", event.Resource.Kind,
@@ -59,25 +94,76 @@ func (s *resourceService) HandleResourceEvent(ctx context.Context, event Resourc
 		"namespace", event.Resource.Namespace,
 		"eventType", event.Type)
 
-	// Apply business logic based on the resource event
-	switch event.Resource.Kind {
-	case "Deployment":
-		slog.Info("Deployment event detected",
-			"name", event.Resource.Name,
-			"namespace", event.Resource.Namespace,
-			"eventType", event.Type)
-		// Add specific deployment processing logic here if needed
-	case "Service":
-		slog.Info("Service event detected",
-			"name", event.Resource.Name,
-			"namespace", event.Resource.Namespace,
-			"eventType", event.Type)
-	case "Pod":
-		slog.Info("Pod event detected",
-			"name", event.Resource.Name,
-			"namespace", event.Resource.Namespace,
-			"eventType", event.Type)
+	if handler, ok := s.kindHandlers[event.Resource.Kind]; ok {
+		return handler(ctx, event)
 	}
 
+	slog.Info("Resource event detected",
+		"kind", event.Resource.Kind,
+		"name", event.Resource.Name,
+		"namespace", event.Resource.Namespace,
+		"eventType", event.Type)
+	return nil
+}
+
+// RegisterKindHandler sets the handler for events of the resource kind, such as "Deployment",
+// replacing any previous one. Handlers must be registered before events are handled.
+func (s *resourceService) RegisterKindHandler(kind string, handler KindHandler) {
+	s.kindHandlers[kind] = handler
+}
+
+// OnReport registers an observer called with every report produced by RunPeriodicReport.
+// Observers must be registered before the report loop starts.
+func (s *resourceService) OnReport(observer func(DeploymentReport)) {
+	s.reportObservers = append(s.reportObservers, observer)
+}
+
+// RunPeriodicReport scans the cached deployments on every tick of interval, logs a health
+// summary and any policy violations and passes the report to the observers. It runs until
+// the context is cancelled; a failed scan is logged and retried on the next tick.
+func (s *resourceService) RunPeriodicReport(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("report interval must be positive, got %s", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.report(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				slog.Error("Failed to build deployment report", "error", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// report builds one deployment report, logs it and notifies the observers
+func (s *resourceService) report(ctx context.Context) error {
+	deployments, err := s.client.ListWatchedDeployments(ctx)
+	if err != nil {
+		return err
+	}
+
+	report := BuildDeploymentReport(deployments)
+	slog.Info("Deployment health report",
+		"total", report.Health.Total,
+		"ready", report.Health.Ready,
+		"degraded", report.Health.Degraded,
+		"violations", len(report.Violations))
+	for _, violation := range report.Violations {
+		slog.Warn("Deployment policy violation",
+			"policy", violation.Policy,
+			"name", violation.Name,
+			"namespace", violation.Namespace,
+			"message", violation.Message)
+	}
+
+	for _, observer := range s.reportObservers {
+		observer(report)
+	}
 	return nil
 }
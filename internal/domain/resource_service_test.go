@@ -2,15 +2,18 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 )
 
 // MockResourceClient is a mock implementation of the ResourceClient interface
 type MockResourceClient struct {
-	ConnectFunc        func(ctx context.Context) error
-	WatchResourcesFunc func(ctx context.Context) error
-	GetResourceFunc    func(ctx context.Context, kind, name, namespace string) (Resource, error)
-	ApplyResourceFunc  func(ctx context.Context, resource Resource) error
+	ConnectFunc                func(ctx context.Context) error
+	WatchResourcesFunc         func(ctx context.Context) error
+	GetResourceFunc            func(ctx context.Context, kind, name, namespace string) (Resource, error)
+	ApplyResourceFunc          func(ctx context.Context, resource Resource) error
+	ListWatchedDeploymentsFunc func(ctx context.Context) ([]Deployment, error)
 }
 
 func (m *MockResourceClient) Connect(ctx context.Context) error {
@@ -41,12 +44,19 @@ func (m *MockResourceClient) ApplyResource(ctx context.Context, resource Resourc
 	return nil
 }
 
+func (m *MockResourceClient) ListWatchedDeployments(ctx context.Context) ([]Deployment, error) {
+	if m.ListWatchedDeploymentsFunc != nil {
+		return m.ListWatchedDeploymentsFunc(ctx)
+	}
+	return nil, nil
+}
+
 func TestHandleResourceEvent(t *testing.T) {
 	mockClient := &MockResourceClient{}
 	service := NewResourceService(mockClient)
 
 	event := ResourceEvent{
-		Type: "ADDED",
+		Type: ResourceEventCreated,
 		Resource: Resource{
 			Kind:      "Pod",
 			Name:      "test-pod",
@@ -62,3 +72,84 @@ func TestHandleResourceEvent(t *testing.T) {
 	// Here you could add assertions to check if the mock client's methods were called, for example.
 	// For this simple test, we just check that no error is returned.
 }
+
+func TestHandleResourceEventDispatchesByKind(t *testing.T) {
+	service := NewResourceService(&MockResourceClient{})
+
+	var handled []string
+	service.RegisterKindHandler("Deployment", func(ctx context.Context, event ResourceEvent) error {
+		handled = append(handled, event.Resource.Name)
+		return nil
+	})
+	failure := errors.New("invalid config map")
+	service.RegisterKindHandler("ConfigMap", func(ctx context.Context, event ResourceEvent) error {
+		return failure
+	})
+
+	events := []ResourceEvent{
+		{Type: ResourceEventCreated, Resource: Resource{Kind: "Deployment", Name: "web", Namespace: "default"}},
+		{Type: ResourceEventCreated, Resource: Resource{Kind: "Pod", Name: "web-abc", Namespace: "default"}},
+	}
+	for _, event := range events {
+		if err := service.HandleResourceEvent(context.Background(), event); err != nil {
+			t.Errorf("HandleResourceEvent(%s) failed: %v", event.Resource.Kind, err)
+		}
+	}
+	if len(handled) != 1 || handled[0] != "web" {
+		t.Errorf("expected only the deployment to reach its handler, got %v", handled)
+	}
+
+	event := ResourceEvent{Type: ResourceEventUpdated, Resource: Resource{Kind: "ConfigMap", Name: "settings"}}
+	if err := service.HandleResourceEvent(context.Background(), event); !errors.Is(err, failure) {
+		t.Errorf("expected the handler's error, got %v", err)
+	}
+}
+
+func TestRunPeriodicReport(t *testing.T) {
+	mockClient := &MockResourceClient{
+		ListWatchedDeploymentsFunc: func(ctx context.Context) ([]Deployment, error) {
+			return []Deployment{
+				{Name: "web", Namespace: "default", Replicas: 2, ReadyReplicas: 2},
+				{Name: "api", Namespace: "default", Replicas: 1, Annotations: map[string]string{MinReplicasAnnotation: "2"}},
+			}, nil
+		},
+	}
+	service := NewResourceService(mockClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reports := make(chan DeploymentReport, 1)
+	service.OnReport(func(report DeploymentReport) {
+		select {
+		case reports <- report:
+		default:
+		}
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- service.RunPeriodicReport(ctx, 10*time.Millisecond)
+	}()
+
+	select {
+	case report := <-reports:
+		if report.Health.Total != 2 || report.Health.Degraded != 1 {
+			t.Errorf("unexpected health %+v", report.Health)
+		}
+		if len(report.Violations) != 1 || report.Violations[0].Policy != PolicyReplicaFloor || report.Violations[0].Name != "api" {
+			t.Errorf("unexpected violations %+v", report.Violations)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a report")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("RunPeriodicReport returned %v after cancel", err)
+	}
+
+	if err := service.RunPeriodicReport(context.Background(), 0); err == nil {
+		t.Error("expected an error for a zero interval")
+	}
+}
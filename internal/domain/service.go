@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// Service represents a Kubernetes service
+type Service struct {
+	Name      string
+	Namespace string
+	Type      string
+	ClusterIP string
+	Ports     []string
+	Labels    map[string]string
+	CreatedAt time.Time
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Supported deployment sort keys
+const (
+	SortByName  = "name"
+	SortByAge   = "age"
+	SortByReady = "ready"
+)
+
+// ValidateDeploymentSortKey checks that the sort key is supported. An empty key is valid and means no sorting.
+func ValidateDeploymentSortKey(key string) error {
+	switch key {
+	case "", SortByName, SortByAge, SortByReady:
+		return nil
+	default:
+		return fmt.Errorf("unknown sort key %q (supported: %s, %s, %s)", key, SortByName, SortByAge, SortByReady)
+	}
+}
+
+// SortDeployments sorts deployments in place by the given key.
+// Ties are broken by namespace and name so the order is deterministic.
+//   - name: alphabetical
+//   - age: oldest first
+//   - ready: fewest ready replicas first
+func SortDeployments(deployments []Deployment, key string) error {
+	if err := ValidateDeploymentSortKey(key); err != nil {
+		return err
+	}
+	if key == "" {
+		return nil
+	}
+
+	byName := func(a, b Deployment) bool {
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	}
+
+	sort.SliceStable(deployments, func(i, j int) bool {
+		a, b := deployments[i], deployments[j]
+		switch key {
+		case SortByAge:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+		case SortByReady:
+			if a.ReadyReplicas != b.ReadyReplicas {
+				return a.ReadyReplicas < b.ReadyReplicas
+			}
+		}
+		return byName(a, b)
+	})
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSortDeployments(t *testing.T) {
+	now := time.Now()
+	deployments := []Deployment{
+		{Name: "b", ReadyReplicas: 1, CreatedAt: now.Add(-1 * time.Hour)},
+		{Name: "c", ReadyReplicas: 0, CreatedAt: now},
+		{Name: "a", ReadyReplicas: 3, CreatedAt: now.Add(-2 * time.Hour)},
+	}
+
+	tests := []struct {
+		key  string
+		want []string
+	}{
+		{SortByName, []string{"a", "b", "c"}},
+		{SortByAge, []string{"a", "b", "c"}},
+		{SortByReady, []string{"c", "b", "a"}},
+	}
+
+	for _, tt := range tests {
+		sorted := append([]Deployment(nil), deployments...)
+		if err := SortDeployments(sorted, tt.key); err != nil {
+			t.Fatalf("SortDeployments(%q) failed: %v", tt.key, err)
+		}
+		for i, name := range tt.want {
+			if sorted[i].Name != name {
+				t.Errorf("SortDeployments(%q)[%d] = %s, want %s", tt.key, i, sorted[i].Name, name)
+			}
+		}
+	}
+
+	if err := SortDeployments(deployments, "size"); err == nil {
+		t.Error("expected an error for an unknown sort key")
+	}
+}
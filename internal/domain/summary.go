@@ -0,0 +1,57 @@
+package domain
+
+// ResourceSummary holds the number of resources of each kind in a namespace
+type ResourceSummary struct {
+	Deployments int
+	Services    int
+	Pods        int
+	ConfigMaps  int
+}
+
+// Add returns the sum of both summaries
+func (s ResourceSummary) Add(other ResourceSummary) ResourceSummary {
+	return ResourceSummary{
+		Deployments: s.Deployments + other.Deployments,
+		Services:    s.Services + other.Services,
+		Pods:        s.Pods + other.Pods,
+		ConfigMaps:  s.ConfigMaps + other.ConfigMaps,
+	}
+}
+
+// DeploymentHealth aggregates the readiness of a set of deployments
+type DeploymentHealth struct {
+	Total    int
+	Ready    int
+	Degraded int
+	// DegradedDeployments lists degraded deployments as namespace/name
+	DegradedDeployments []string
+}
+
+// SummarizeDeploymentHealth counts ready and degraded deployments. A deployment is
+// degraded when fewer replicas are ready than desired.
+func SummarizeDeploymentHealth(deployments []Deployment) DeploymentHealth {
+	health := DeploymentHealth{Total: len(deployments), DegradedDeployments: []string{}}
+	for _, deployment := range deployments {
+		if deployment.IsReady() {
+			health.Ready++
+			continue
+		}
+		health.Degraded++
+		health.DegradedDeployments = append(health.DegradedDeployments, deployment.Namespace+"/"+deployment.Name)
+	}
+	return health
+}
+
+// DeploymentReport is the periodic health and policy report over all watched deployments
+type DeploymentReport struct {
+	Health     DeploymentHealth
+	Violations []PolicyViolation
+}
+
+// BuildDeploymentReport summarizes the health and policy violations of the deployments
+func BuildDeploymentReport(deployments []Deployment) DeploymentReport {
+	return DeploymentReport{
+		Health:     SummarizeDeploymentHealth(deployments),
+		Violations: CheckDeploymentPolicies(deployments),
+	}
+}
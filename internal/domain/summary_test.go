@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSummarizeDeploymentHealth(t *testing.T) {
+	health := SummarizeDeploymentHealth([]Deployment{
+		{Name: "web", Namespace: "default", Replicas: 2, ReadyReplicas: 2},
+		{Name: "api", Namespace: "default", Replicas: 3, ReadyReplicas: 1},
+		{Name: "db", Namespace: "data", Replicas: 1, ReadyReplicas: 0},
+	})
+
+	if health.Total != 3 || health.Ready != 1 || health.Degraded != 2 {
+		t.Errorf("unexpected counts %+v", health)
+	}
+	if want := []string{"default/api", "data/db"}; !slices.Equal(health.DegradedDeployments, want) {
+		t.Errorf("expected degraded %v, got %v", want, health.DegradedDeployments)
+	}
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError describes a problem with a single field of a resource
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when a resource fails validation before being sent to the API server.
+// HTTP handlers should report it as 400 Bad Request with the field errors.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fieldErr := range e.Errors {
+		messages = append(messages, fmt.Sprintf("%s: %s", fieldErr.Field, fieldErr.Message))
+	}
+	return "invalid resource: " + strings.Join(messages, "; ")
+}
+
+// ValidateResource checks the fields required to build a valid object for the resource kind.
+// Deployments need a name and a non-empty container image in Data["image"].
+func ValidateResource(resource Resource) error {
+	var errs []FieldError
+
+	if strings.TrimSpace(resource.Name) == "" {
+		errs = append(errs, FieldError{Field: "name", Message: "must not be empty"})
+	}
+
+	if resource.Kind == "Deployment" {
+		image, _ := resource.Data["image"].(string)
+		if strings.TrimSpace(image) == "" {
+			errs = append(errs, FieldError{Field: "image", Message: "container image must not be empty"})
+		}
+	}
+
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
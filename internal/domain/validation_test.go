@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateResource(t *testing.T) {
+	valid := Resource{Kind: "Deployment", Name: "web", Data: map[string]interface{}{"image": "nginx:1.27"}}
+	if err := ValidateResource(valid); err != nil {
+		t.Errorf("expected valid deployment, got %v", err)
+	}
+
+	err := ValidateResource(Resource{Kind: "Deployment", Name: " ", Data: map[string]interface{}{"image": ""}})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a ValidationError, got %v", err)
+	}
+	if len(validationErr.Errors) != 2 {
+		t.Fatalf("expected 2 field errors, got %d: %v", len(validationErr.Errors), validationErr.Errors)
+	}
+	if validationErr.Errors[0].Field != "name" || validationErr.Errors[1].Field != "image" {
+		t.Errorf("unexpected field errors: %v", validationErr.Errors)
+	}
+
+	// Other kinds don't need an image
+	if err := ValidateResource(Resource{Kind: "ConfigMap", Name: "settings"}); err != nil {
+		t.Errorf("expected valid config map, got %v", err)
+	}
+}
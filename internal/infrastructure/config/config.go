@@ -1,30 +1,137 @@
 package config
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
+// ErrListFile is returned by Load when a namespaces or resources file can't be read
+var ErrListFile = errors.New("cannot read list file")
+
+// ErrNoNamespaces is returned by Load when the namespaces were set but none is left after trimming,
+// e.g. --namespaces ","
+var ErrNoNamespaces = errors.New("no valid namespaces provided")
+
+// Policies for a namespace list that is empty after trimming, set with kubernetes.empty-namespaces
+const (
+	// EmptyNamespacesError makes Load fail with ErrNoNamespaces
+	EmptyNamespacesError = "error"
+	// EmptyNamespacesAll watches all namespaces, discovered at startup
+	EmptyNamespacesAll = "all"
+)
+
+// Policies for a full event buffer, set with controller.event-buffer.policy
+const (
+	// EventBufferBlock makes the informer callbacks wait until the worker frees space
+	EventBufferBlock = "block"
+	// EventBufferDrop drops events that don't fit and counts them in a metric
+	EventBufferDrop = "drop"
+)
+
 // Config represents the application configuration
 type Config struct {
-	LogLevel              string
-	KubeconfigPath        string
-	ResourceNamespaces    []string
-	WatchedResources      []string
-	ServerPort            int
-	EnableLeaderElection  bool
-	LeaderElectionID      string
+	LogLevel                string
+	KubeconfigPath          string
+	KubeContext             string
+	KubeQPS                 float32
+	KubeBurst               int
+	ApplyAttempts           int
+	ResourceNamespaces      []string
+	DiscoverNamespaces      bool
+	ClusterScopeThreshold   int
+	PerNamespaceInformers   bool
+	WatchedResources        []string
+	IgnoreUnknownResources  bool
+	IndexLabels             []string
+	WatchSelector           string
+	WatchSelectors          map[string]string
+	ResyncPeriod            time.Duration
+	ResyncPeriods           map[string]time.Duration
+	ListCacheTTL            time.Duration
+	ServerPort              int
+	TLSCertFile             string
+	TLSKeyFile              string
+	TLSClientCA             string
+	EventReplay             bool
+	EnableLeaderElection    bool
+	LeaderElectionID        string
 	LeaderElectionNamespace string
+	EventTypes              []string
+	MaxEventRetries         int
+	EventBufferSize         int
+	EventBufferPolicy       string
+	ShutdownTimeout         time.Duration
+	ReportInterval          time.Duration
+	ReconcileNamespaces     []string
+	MaxConcurrentReconciles int
+	ReconcileStallTimeout   time.Duration
+	TransientRequeueDelay   time.Duration
+	PermanentRequeueDelay   time.Duration
+	PortFallback            bool
+	ImpersonateUser         string
+	ImpersonateGroups       []string
+	CheckPermissions        bool
+	WebhookEnabled          bool
+	WebhookPort             int
+	WebhookCertDir          string
+	WebhookServiceName      string
+	WebhookServiceNamespace string
+	WebhookDefaultLabels    map[string]string
+	WebhookMinReplicas      int
+	WebhookRequiredLabels   []string
+	AuditEnabled            bool
+	AuditConfigMap          string
+	AuditMaxEntries         int
+	OTelEndpoint            string
+	MetricsNamespaceLabel   bool
+	MetricsBindAddress      string
+	NamespacesFile          string
+	ResourcesFile           string
+	EmptyNamespaces         string
 }
 
 // Default returns a configuration with default values
 func Default() *Config {
 	return &Config{
-		LogLevel:           "INFO",
-		ResourceNamespaces: []string{"default"},
-		WatchedResources:   []string{"deployments", "services"},
-		ServerPort:         8080,
+		LogLevel:                "INFO",
+		ResourceNamespaces:      []string{"default"},
+		KubeQPS:                 20,
+		KubeBurst:               30,
+		ApplyAttempts:           5,
+		ClusterScopeThreshold:   10,
+		WatchedResources:        []string{"deployments", "services", "pods"},
+		ResyncPeriod:            30 * time.Second,
+		ResyncPeriods:           map[string]time.Duration{},
+		WatchSelectors:          map[string]string{},
+		ServerPort:              8080,
+		MaxEventRetries:         5,
+		EventBufferPolicy:       EventBufferBlock,
+		ShutdownTimeout:         10 * time.Second,
+		ReportInterval:          5 * time.Minute,
+		MaxConcurrentReconciles: 1,
+		ReconcileStallTimeout:   10 * time.Minute,
+		TransientRequeueDelay:   5 * time.Second,
+		CheckPermissions:        true,
+		WebhookPort:             9443,
+		WebhookCertDir:          filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs"),
+		WebhookServiceName:      "k8s-controller",
+		WebhookServiceNamespace: "k8s-controller-system",
+		WebhookDefaultLabels:    map[string]string{"managed-by": "k8s-controller"},
+		WebhookMinReplicas:      1,
+		WebhookRequiredLabels:   []string{"managed-by"},
+		AuditConfigMap:          "default/k8s-controller-audit",
+		AuditMaxEntries:         200,
+		MetricsNamespaceLabel:   true,
+		MetricsBindAddress:      ":8081",
+		EmptyNamespaces:         EmptyNamespacesError,
 	}
 }
 
@@ -42,18 +149,154 @@ func Load() (*Config, error) {
 		cfg.KubeconfigPath = viper.GetString("kubernetes.kubeconfig")
 	}
 
+	if viper.IsSet("kubernetes.context") {
+		cfg.KubeContext = viper.GetString("kubernetes.context")
+	}
+
+	if viper.IsSet("kubernetes.qps") {
+		cfg.KubeQPS = float32(viper.GetFloat64("kubernetes.qps"))
+	}
+
+	if viper.IsSet("kubernetes.burst") {
+		cfg.KubeBurst = viper.GetInt("kubernetes.burst")
+	}
+
+	if viper.IsSet("kubernetes.apply-attempts") {
+		cfg.ApplyAttempts = viper.GetInt("kubernetes.apply-attempts")
+	}
+
 	if viper.IsSet("kubernetes.namespaces") {
 		cfg.ResourceNamespaces = getStringSlice("kubernetes.namespaces")
 	}
 
+	if viper.IsSet("kubernetes.discover-namespaces") {
+		cfg.DiscoverNamespaces = viper.GetBool("kubernetes.discover-namespaces")
+	}
+
+	if viper.IsSet("kubernetes.cluster-scope-threshold") {
+		cfg.ClusterScopeThreshold = viper.GetInt("kubernetes.cluster-scope-threshold")
+	}
+
+	if viper.IsSet("kubernetes.per-namespace-informers") {
+		cfg.PerNamespaceInformers = viper.GetBool("kubernetes.per-namespace-informers")
+	}
+
 	if viper.IsSet("kubernetes.resources") {
 		cfg.WatchedResources = getStringSlice("kubernetes.resources")
 	}
 
+	// Entries from list files are added to the inline values, or replace the defaults without them
+	if viper.IsSet("kubernetes.namespaces-file") {
+		cfg.NamespacesFile = viper.GetString("kubernetes.namespaces-file")
+	}
+	if cfg.NamespacesFile != "" {
+		namespaces, err := readListFile(cfg.NamespacesFile)
+		if err != nil {
+			return cfg, fmt.Errorf("kubernetes.namespaces-file: %w: %w", ErrListFile, err)
+		}
+		cfg.ResourceNamespaces = mergeLists(viper.IsSet("kubernetes.namespaces"), cfg.ResourceNamespaces, namespaces)
+	}
+
+	// An explicit list like "," is empty after trimming; don't silently fall back to "default"
+	if viper.IsSet("kubernetes.empty-namespaces") {
+		cfg.EmptyNamespaces = strings.ToLower(viper.GetString("kubernetes.empty-namespaces"))
+	}
+	namespacesSet := viper.IsSet("kubernetes.namespaces") || cfg.NamespacesFile != ""
+	if namespacesSet && len(cfg.ResourceNamespaces) == 0 && !cfg.DiscoverNamespaces {
+		if cfg.EmptyNamespaces != EmptyNamespacesAll {
+			return cfg, fmt.Errorf("kubernetes.namespaces: %w", ErrNoNamespaces)
+		}
+		slog.Warn("No valid namespaces provided, watching all namespaces", "policy", "kubernetes.empty-namespaces="+EmptyNamespacesAll)
+		cfg.DiscoverNamespaces = true
+	}
+
+	if viper.IsSet("kubernetes.resources-file") {
+		cfg.ResourcesFile = viper.GetString("kubernetes.resources-file")
+	}
+	if cfg.ResourcesFile != "" {
+		resources, err := readListFile(cfg.ResourcesFile)
+		if err != nil {
+			return cfg, fmt.Errorf("kubernetes.resources-file: %w: %w", ErrListFile, err)
+		}
+		cfg.WatchedResources = mergeLists(viper.IsSet("kubernetes.resources"), cfg.WatchedResources, resources)
+	}
+
+	if viper.IsSet("kubernetes.ignore-unknown-resources") {
+		cfg.IgnoreUnknownResources = viper.GetBool("kubernetes.ignore-unknown-resources")
+	}
+
+	if viper.IsSet("kubernetes.index-labels") {
+		cfg.IndexLabels = getStringSlice("kubernetes.index-labels")
+	}
+
+	if viper.IsSet("kubernetes.watch-selector") {
+		cfg.WatchSelector = viper.GetString("kubernetes.watch-selector")
+	}
+
+	if viper.IsSet("kubernetes.selectors") {
+		cfg.WatchSelectors = viper.GetStringMapString("kubernetes.selectors")
+	}
+
+	if viper.IsSet("kubernetes.resync-period") {
+		cfg.ResyncPeriod = viper.GetDuration("kubernetes.resync-period")
+	}
+
+	if viper.IsSet("kubernetes.resync") {
+		periods, err := getDurationMap("kubernetes.resync")
+		if err != nil {
+			return cfg, err
+		}
+		cfg.ResyncPeriods = periods
+	}
+
+	if viper.IsSet("kubernetes.list-cache-ttl") {
+		cfg.ListCacheTTL = viper.GetDuration("kubernetes.list-cache-ttl")
+	}
+
+	if viper.IsSet("controller.audit.enabled") {
+		cfg.AuditEnabled = viper.GetBool("controller.audit.enabled")
+	}
+
+	if viper.IsSet("controller.audit.configmap") {
+		cfg.AuditConfigMap = viper.GetString("controller.audit.configmap")
+	}
+
+	if viper.IsSet("controller.audit.max-entries") {
+		cfg.AuditMaxEntries = viper.GetInt("controller.audit.max-entries")
+	}
+
+	if viper.IsSet("controller.check-permissions") {
+		cfg.CheckPermissions = viper.GetBool("controller.check-permissions")
+	}
+
+	if viper.IsSet("kubernetes.impersonate.user") {
+		cfg.ImpersonateUser = viper.GetString("kubernetes.impersonate.user")
+	}
+
+	if viper.IsSet("kubernetes.impersonate.groups") {
+		cfg.ImpersonateGroups = getStringSlice("kubernetes.impersonate.groups")
+	}
+
 	if viper.IsSet("server.port") {
 		cfg.ServerPort = viper.GetInt("server.port")
 	}
 
+	if viper.IsSet("server.tls.cert-file") {
+		cfg.TLSCertFile = viper.GetString("server.tls.cert-file")
+	}
+
+	if viper.IsSet("server.tls.key-file") {
+		cfg.TLSKeyFile = viper.GetString("server.tls.key-file")
+	}
+
+	if viper.IsSet("server.tls.client-ca") {
+		cfg.TLSClientCA = viper.GetString("server.tls.client-ca")
+	}
+
+	if viper.IsSet("server.event-replay") {
+		cfg.EventReplay = viper.GetBool("server.event-replay")
+	}
+
 	if viper.IsSet("leader-election.enabled") {
 		cfg.EnableLeaderElection = viper.GetBool("leader-election.enabled")
 	}
@@ -66,17 +309,117 @@ func Load() (*Config, error) {
 		cfg.LeaderElectionNamespace = viper.GetString("leader-election.namespace")
 	}
 
+	if viper.IsSet("controller.event-types") {
+		cfg.EventTypes = getStringSlice("controller.event-types")
+	}
+
+	if viper.IsSet("controller.max-retries") {
+		cfg.MaxEventRetries = viper.GetInt("controller.max-retries")
+	}
+
+	if viper.IsSet("controller.event-buffer.size") {
+		cfg.EventBufferSize = viper.GetInt("controller.event-buffer.size")
+	}
+
+	if viper.IsSet("controller.event-buffer.policy") {
+		cfg.EventBufferPolicy = strings.ToLower(strings.TrimSpace(viper.GetString("controller.event-buffer.policy")))
+	}
+
+	if viper.IsSet("controller.shutdown-timeout") {
+		cfg.ShutdownTimeout = viper.GetDuration("controller.shutdown-timeout")
+	}
+
+	if viper.IsSet("controller.report-interval") {
+		cfg.ReportInterval = viper.GetDuration("controller.report-interval")
+	}
+
+	if viper.IsSet("controller.reconcile-namespaces") {
+		cfg.ReconcileNamespaces = getStringSlice("controller.reconcile-namespaces")
+	}
+
+	if viper.IsSet("controller.max-concurrent-reconciles") {
+		cfg.MaxConcurrentReconciles = viper.GetInt("controller.max-concurrent-reconciles")
+	}
+
+	if viper.IsSet("controller.reconcile-stall-timeout") {
+		cfg.ReconcileStallTimeout = viper.GetDuration("controller.reconcile-stall-timeout")
+	}
+
+	if viper.IsSet("controller.requeue.transient-delay") {
+		cfg.TransientRequeueDelay = viper.GetDuration("controller.requeue.transient-delay")
+	}
+
+	if viper.IsSet("controller.requeue.permanent-delay") {
+		cfg.PermanentRequeueDelay = viper.GetDuration("controller.requeue.permanent-delay")
+	}
+
+	if viper.IsSet("controller.port-fallback") {
+		cfg.PortFallback = viper.GetBool("controller.port-fallback")
+	}
+
+	if viper.IsSet("webhook.enabled") {
+		cfg.WebhookEnabled = viper.GetBool("webhook.enabled")
+	}
+
+	if viper.IsSet("webhook.port") {
+		cfg.WebhookPort = viper.GetInt("webhook.port")
+	}
+
+	if viper.IsSet("webhook.cert-dir") {
+		cfg.WebhookCertDir = viper.GetString("webhook.cert-dir")
+	}
+
+	if viper.IsSet("webhook.service-name") {
+		cfg.WebhookServiceName = viper.GetString("webhook.service-name")
+	}
+
+	if viper.IsSet("webhook.service-namespace") {
+		cfg.WebhookServiceNamespace = viper.GetString("webhook.service-namespace")
+	}
+
+	if viper.IsSet("webhook.default-labels") {
+		cfg.WebhookDefaultLabels = viper.GetStringMapString("webhook.default-labels")
+	}
+
+	if viper.IsSet("webhook.min-replicas") {
+		cfg.WebhookMinReplicas = viper.GetInt("webhook.min-replicas")
+	}
+
+	if viper.IsSet("webhook.required-labels") {
+		cfg.WebhookRequiredLabels = getStringSlice("webhook.required-labels")
+	}
+
+	if viper.IsSet("metrics.namespace-label") {
+		cfg.MetricsNamespaceLabel = viper.GetBool("metrics.namespace-label")
+	}
+	if viper.IsSet("metrics.bind-address") {
+		cfg.MetricsBindAddress = viper.GetString("metrics.bind-address")
+	}
+
+	if viper.IsSet("otel.endpoint") {
+		cfg.OTelEndpoint = viper.GetString("otel.endpoint")
+	}
+
 	return cfg, nil
 }
 
-// getStringSlice safely gets a string slice from viper
+// getStringSlice safely gets a string slice from viper.
+// Values may be a comma-separated string or a list of strings.
 func getStringSlice(key string) []string {
-	val := viper.GetString(key)
-	if val == "" {
-		return []string{}
+	var items []string
+	switch val := viper.Get(key).(type) {
+	case string:
+		items = strings.Split(val, ",")
+	case []string:
+		for _, item := range val {
+			items = append(items, strings.Split(item, ",")...)
+		}
+	case []interface{}:
+		for _, item := range val {
+			items = append(items, strings.Split(fmt.Sprint(item), ",")...)
+		}
 	}
 
-	items := strings.Split(val, ",")
 	result := make([]string, 0, len(items))
 
 	// Trim whitespace from each item
@@ -89,3 +432,63 @@ func getStringSlice(key string) []string {
 
 	return result
 }
+
+// readListFile reads one entry per line from path. Blank lines and lines starting with # are skipped.
+func readListFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// mergeLists appends the file entries to the inline ones, skipping duplicates. Without inline
+// values the file entries replace the current (default) ones.
+func mergeLists(inlineSet bool, current, fromFile []string) []string {
+	var merged []string
+	if inlineSet {
+		merged = append(merged, current...)
+	}
+
+	seen := make(map[string]bool, len(merged)+len(fromFile))
+	for _, entry := range merged {
+		seen[entry] = true
+	}
+	for _, entry := range fromFile {
+		if !seen[entry] {
+			seen[entry] = true
+			merged = append(merged, entry)
+		}
+	}
+	return merged
+}
+
+// getDurationMap reads a map of resource names to durations from viper
+func getDurationMap(key string) (map[string]time.Duration, error) {
+	raw := viper.GetStringMapString(key)
+	result := make(map[string]time.Duration, len(raw))
+
+	for name, val := range raw {
+		period, err := time.ParseDuration(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q for %s.%s: %w", val, key, name, err)
+		}
+		result[strings.ToLower(strings.TrimSpace(name))] = period
+	}
+
+	return result, nil
+}
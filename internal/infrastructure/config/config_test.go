@@ -0,0 +1,74 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestLoadMergesListFiles(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	dir := t.TempDir()
+
+	namespacesFile := filepath.Join(dir, "namespaces.txt")
+	if err := os.WriteFile(namespacesFile, []byte("# payments team\nteam-a\n\n  team-b  \nkube-system\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	resourcesFile := filepath.Join(dir, "resources.txt")
+	if err := os.WriteFile(resourcesFile, []byte("pods\nconfigmaps\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Set("kubernetes.namespaces", "kube-system")
+	viper.Set("kubernetes.namespaces-file", namespacesFile)
+	viper.Set("kubernetes.resources-file", resourcesFile)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	// Inline namespaces come first and duplicates are dropped
+	if want := []string{"kube-system", "team-a", "team-b"}; !slices.Equal(cfg.ResourceNamespaces, want) {
+		t.Errorf("expected namespaces %v, got %v", want, cfg.ResourceNamespaces)
+	}
+	// Without inline resources the file replaces the defaults
+	if want := []string{"pods", "configmaps"}; !slices.Equal(cfg.WatchedResources, want) {
+		t.Errorf("expected resources %v, got %v", want, cfg.WatchedResources)
+	}
+}
+
+func TestLoadReportsMissingListFile(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("kubernetes.namespaces-file", filepath.Join(t.TempDir(), "missing.txt"))
+
+	_, err := Load()
+	if !errors.Is(err, ErrListFile) {
+		t.Fatalf("expected ErrListFile, got %v", err)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected the error to say the file does not exist, got %v", err)
+	}
+}
+
+func TestLoadEmptyNamespacesPolicy(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("kubernetes.namespaces", " , ")
+
+	if _, err := Load(); !errors.Is(err, ErrNoNamespaces) {
+		t.Fatalf("expected ErrNoNamespaces by default, got %v", err)
+	}
+
+	viper.Set("kubernetes.empty-namespaces", EmptyNamespacesAll)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !cfg.DiscoverNamespaces {
+		t.Error("expected the all policy to watch all namespaces")
+	}
+}
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"k8s-controller/internal/domain"
+)
+
+// Validate checks the configuration for values that would fail or misbehave at runtime.
+// All problems are reported together.
+func (c *Config) Validate() error {
+	var errs []error
+
+	switch strings.ToUpper(c.LogLevel) {
+	case "DEBUG", "INFO", "WARN", "ERROR":
+	default:
+		errs = append(errs, fmt.Errorf("log.level: unknown level %q (expected DEBUG, INFO, WARN or ERROR)", c.LogLevel))
+	}
+
+	if len(c.ResourceNamespaces) == 0 && !c.DiscoverNamespaces {
+		errs = append(errs, errors.New("kubernetes.namespaces: at least one namespace is required unless discover-namespaces is enabled"))
+	}
+	switch c.EmptyNamespaces {
+	case EmptyNamespacesError, EmptyNamespacesAll:
+	default:
+		errs = append(errs, fmt.Errorf("kubernetes.empty-namespaces: unknown policy %q (expected %s or %s)", c.EmptyNamespaces, EmptyNamespacesError, EmptyNamespacesAll))
+	}
+	if len(c.WatchedResources) == 0 {
+		errs = append(errs, errors.New("kubernetes.resources: at least one resource is required"))
+	}
+	if c.KubeQPS < 0 {
+		errs = append(errs, fmt.Errorf("kubernetes.qps: must not be negative, got %g", c.KubeQPS))
+	}
+	if c.KubeBurst < 0 {
+		errs = append(errs, fmt.Errorf("kubernetes.burst: must not be negative, got %d", c.KubeBurst))
+	}
+	if c.ApplyAttempts < 1 {
+		errs = append(errs, fmt.Errorf("kubernetes.apply-attempts: must be at least 1, got %d", c.ApplyAttempts))
+	}
+	if c.ClusterScopeThreshold < 0 {
+		errs = append(errs, fmt.Errorf("kubernetes.cluster-scope-threshold: must not be negative, got %d", c.ClusterScopeThreshold))
+	}
+	if _, err := labels.Parse(c.WatchSelector); err != nil {
+		errs = append(errs, fmt.Errorf("kubernetes.watch-selector: %w", err))
+	}
+	for resource, selector := range c.WatchSelectors {
+		if _, err := labels.Parse(selector); err != nil {
+			errs = append(errs, fmt.Errorf("kubernetes.selectors.%s: %w", resource, err))
+		}
+	}
+	if c.ResyncPeriod <= 0 {
+		errs = append(errs, fmt.Errorf("kubernetes.resync-period: must be positive, got %s", c.ResyncPeriod))
+	}
+	if c.ListCacheTTL < 0 {
+		errs = append(errs, fmt.Errorf("kubernetes.list-cache-ttl: must not be negative, got %s", c.ListCacheTTL))
+	}
+	for resource, period := range c.ResyncPeriods {
+		if period <= 0 {
+			errs = append(errs, fmt.Errorf("kubernetes.resync.%s: must be positive, got %s", resource, period))
+		}
+	}
+	if c.ImpersonateUser == "" && len(c.ImpersonateGroups) > 0 {
+		errs = append(errs, errors.New("kubernetes.impersonate.groups: requires kubernetes.impersonate.user"))
+	}
+
+	if err := validatePort(c.ServerPort); err != nil {
+		errs = append(errs, fmt.Errorf("server.port: %w", err))
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		errs = append(errs, errors.New("server.tls: cert-file and key-file must be set together"))
+	}
+	if c.TLSClientCA != "" && c.TLSCertFile == "" {
+		errs = append(errs, errors.New("server.tls.client-ca: requires server.tls.cert-file and key-file"))
+	}
+
+	for _, eventType := range c.EventTypes {
+		if _, err := domain.ParseEventType(eventType); err != nil {
+			errs = append(errs, fmt.Errorf("controller.event-types: %w", err))
+		}
+	}
+	if c.MaxEventRetries < 0 {
+		errs = append(errs, fmt.Errorf("controller.max-retries: must not be negative, got %d", c.MaxEventRetries))
+	}
+	if c.EventBufferSize < 0 {
+		errs = append(errs, fmt.Errorf("controller.event-buffer.size: must not be negative, got %d", c.EventBufferSize))
+	}
+	switch c.EventBufferPolicy {
+	case EventBufferBlock, EventBufferDrop:
+	default:
+		errs = append(errs, fmt.Errorf("controller.event-buffer.policy: unknown policy %q (expected %s or %s)", c.EventBufferPolicy, EventBufferBlock, EventBufferDrop))
+	}
+	if c.ShutdownTimeout < 0 {
+		errs = append(errs, fmt.Errorf("controller.shutdown-timeout: must not be negative, got %s", c.ShutdownTimeout))
+	}
+	if c.ReportInterval < 0 {
+		errs = append(errs, fmt.Errorf("controller.report-interval: must not be negative, got %s", c.ReportInterval))
+	}
+	if c.MaxConcurrentReconciles < 1 {
+		errs = append(errs, fmt.Errorf("controller.max-concurrent-reconciles: must be at least 1, got %d", c.MaxConcurrentReconciles))
+	}
+	if c.ReconcileStallTimeout < 0 {
+		errs = append(errs, fmt.Errorf("controller.reconcile-stall-timeout: must not be negative, got %s", c.ReconcileStallTimeout))
+	}
+	if c.TransientRequeueDelay <= 0 {
+		errs = append(errs, fmt.Errorf("controller.requeue.transient-delay: must be positive, got %s", c.TransientRequeueDelay))
+	}
+	if c.PermanentRequeueDelay < 0 {
+		errs = append(errs, fmt.Errorf("controller.requeue.permanent-delay: must not be negative, got %s", c.PermanentRequeueDelay))
+	}
+	if c.AuditEnabled {
+		if c.AuditConfigMap == "" {
+			errs = append(errs, errors.New("controller.audit.configmap: required when the audit log is enabled"))
+		}
+		if c.AuditMaxEntries <= 0 {
+			errs = append(errs, fmt.Errorf("controller.audit.max-entries: must be positive, got %d", c.AuditMaxEntries))
+		}
+	}
+
+	if c.WebhookEnabled {
+		if err := validatePort(c.WebhookPort); err != nil {
+			errs = append(errs, fmt.Errorf("webhook.port: %w", err))
+		}
+		if c.WebhookCertDir == "" {
+			errs = append(errs, errors.New("webhook.cert-dir: required when webhooks are enabled"))
+		}
+		if c.WebhookServiceName == "" || c.WebhookServiceNamespace == "" {
+			errs = append(errs, errors.New("webhook.service-name and webhook.service-namespace: required when webhooks are enabled"))
+		}
+		if c.WebhookMinReplicas < 0 {
+			errs = append(errs, fmt.Errorf("webhook.min-replicas: must not be negative, got %d", c.WebhookMinReplicas))
+		}
+	}
+
+	if c.MetricsBindAddress != "" {
+		if _, _, err := net.SplitHostPort(c.MetricsBindAddress); err != nil {
+			errs = append(errs, fmt.Errorf("metrics.bind-address: expected host:port such as :8081, got %q", c.MetricsBindAddress))
+		}
+	}
+
+	if c.OTelEndpoint != "" {
+		if endpoint, err := url.Parse(c.OTelEndpoint); err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			errs = append(errs, fmt.Errorf("otel.endpoint: expected an http or https URL such as http://otel-collector:4318, got %q", c.OTelEndpoint))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validatePort checks that port is a valid TCP port number
+func validatePort(port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("must be between 1 and 65535, got %d", port)
+	}
+	return nil
+}
+
+// Settings returns the configuration as nested maps keyed like the configuration file,
+// with durations rendered as strings
+func (c *Config) Settings() map[string]interface{} {
+	resync := make(map[string]string, len(c.ResyncPeriods))
+	for resource, period := range c.ResyncPeriods {
+		resync[resource] = period.String()
+	}
+
+	return map[string]interface{}{
+		"log": map[string]interface{}{
+			"level": c.LogLevel,
+		},
+		"kubernetes": map[string]interface{}{
+			"kubeconfig":               c.KubeconfigPath,
+			"context":                  c.KubeContext,
+			"qps":                      c.KubeQPS,
+			"burst":                    c.KubeBurst,
+			"apply-attempts":           c.ApplyAttempts,
+			"namespaces":               c.ResourceNamespaces,
+			"namespaces-file":          c.NamespacesFile,
+			"empty-namespaces":         c.EmptyNamespaces,
+			"discover-namespaces":      c.DiscoverNamespaces,
+			"cluster-scope-threshold":  c.ClusterScopeThreshold,
+			"per-namespace-informers":  c.PerNamespaceInformers,
+			"resources":                c.WatchedResources,
+			"resources-file":           c.ResourcesFile,
+			"ignore-unknown-resources": c.IgnoreUnknownResources,
+			"index-labels":             c.IndexLabels,
+			"watch-selector":           c.WatchSelector,
+			"selectors":                c.WatchSelectors,
+			"resync-period":            c.ResyncPeriod.String(),
+			"resync":                   resync,
+			"list-cache-ttl":           c.ListCacheTTL.String(),
+			"impersonate": map[string]interface{}{
+				"user":   c.ImpersonateUser,
+				"groups": c.ImpersonateGroups,
+			},
+		},
+		"server": map[string]interface{}{
+			"port":         c.ServerPort,
+			"event-replay": c.EventReplay,
+			"tls": map[string]interface{}{
+				"cert-file": c.TLSCertFile,
+				"key-file":  c.TLSKeyFile,
+				"client-ca": c.TLSClientCA,
+			},
+		},
+		"leader-election": map[string]interface{}{
+			"enabled":   c.EnableLeaderElection,
+			"id":        c.LeaderElectionID,
+			"namespace": c.LeaderElectionNamespace,
+		},
+		"controller": map[string]interface{}{
+			"event-types":               c.EventTypes,
+			"max-retries":               c.MaxEventRetries,
+			"shutdown-timeout":          c.ShutdownTimeout.String(),
+			"report-interval":           c.ReportInterval.String(),
+			"reconcile-namespaces":      c.ReconcileNamespaces,
+			"max-concurrent-reconciles": c.MaxConcurrentReconciles,
+			"reconcile-stall-timeout":   c.ReconcileStallTimeout.String(),
+			"check-permissions":         c.CheckPermissions,
+			"port-fallback":             c.PortFallback,
+			"event-buffer": map[string]interface{}{
+				"size":   c.EventBufferSize,
+				"policy": c.EventBufferPolicy,
+			},
+			"requeue": map[string]interface{}{
+				"transient-delay": c.TransientRequeueDelay.String(),
+				"permanent-delay": c.PermanentRequeueDelay.String(),
+			},
+			"audit": map[string]interface{}{
+				"enabled":     c.AuditEnabled,
+				"configmap":   c.AuditConfigMap,
+				"max-entries": c.AuditMaxEntries,
+			},
+		},
+		"webhook": map[string]interface{}{
+			"enabled":           c.WebhookEnabled,
+			"port":              c.WebhookPort,
+			"cert-dir":          c.WebhookCertDir,
+			"service-name":      c.WebhookServiceName,
+			"service-namespace": c.WebhookServiceNamespace,
+			"default-labels":    c.WebhookDefaultLabels,
+			"min-replicas":      c.WebhookMinReplicas,
+			"required-labels":   c.WebhookRequiredLabels,
+		},
+		"metrics": map[string]interface{}{
+			"namespace-label": c.MetricsNamespaceLabel,
+			"bind-address":    c.MetricsBindAddress,
+		},
+		"otel": map[string]interface{}{
+			"endpoint": c.OTelEndpoint,
+		},
+	}
+}
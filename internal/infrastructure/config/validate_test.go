@@ -0,0 +1,40 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefaultConfigIsValid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Fatalf("expected default config to be valid, got %v", err)
+	}
+}
+
+func TestValidateReportsAllProblems(t *testing.T) {
+	cfg := Default()
+	cfg.LogLevel = "verbose"
+	cfg.ServerPort = 0
+	cfg.ResyncPeriods = map[string]time.Duration{"pods": 0}
+	cfg.EventTypes = []string{"created", "patched"}
+	cfg.WebhookEnabled = true
+	cfg.WebhookPort = 70000
+	cfg.MaxConcurrentReconciles = 0
+	cfg.OTelEndpoint = "otel-collector:4318"
+	cfg.EventBufferPolicy = "spill"
+	cfg.TLSClientCA = "ca.crt"
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"log.level", "server.port", "kubernetes.resync.pods", `"patched"`, "webhook.port", "controller.max-concurrent-reconciles", "otel.endpoint", "controller.event-buffer.policy", "server.tls.client-ca"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), `"created"`) {
+		t.Errorf("event types should be case-insensitive, got %q", err)
+	}
+}
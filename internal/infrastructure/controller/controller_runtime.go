@@ -2,23 +2,38 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"k8s-controller/internal/infrastructure/config"
+	"k8s-controller/internal/infrastructure/kubernetes"
 )
 
+// ErrNoClusterConfig is returned by NewControllerRuntime when no kubeconfig or in-cluster
+// configuration is available
+var ErrNoClusterConfig = errors.New("no Kubernetes cluster configuration found")
+
 // ControllerRuntime encapsulates the controller-runtime manager and client
 type ControllerRuntime struct {
 	manager        manager.Manager
@@ -30,6 +45,7 @@ type ControllerRuntime struct {
 	reconcilers    map[string]reconcile.Reconciler
 	metricsAddress string
 	healthAddress  string
+	config         *config.Config
 }
 
 // NewControllerRuntime creates a new controller runtime instance
@@ -42,9 +58,28 @@ func NewControllerRuntime(cfg *config.Config) (*ControllerRuntime, error) {
 	if err := corev1.AddToScheme(scheme); err != nil {
 		return nil, fmt.Errorf("error adding core/v1 to scheme: %w", err)
 	}
+	if err := autoscalingv1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("error adding autoscaling/v1 to scheme: %w", err)
+	}
+	if err := batchv1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("error adding batch/v1 to scheme: %w", err)
+	}
+	if err := networkingv1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("error adding networking/v1 to scheme: %w", err)
+	}
+	if err := admissionregistrationv1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("error adding admissionregistration/v1 to scheme: %w", err)
+	}
 
-	metricsAddr := ":8081"
-	healthAddr := ":8082"
+	// Fail when another process holds the defaults, unless falling back to free ports was asked for
+	metricsAddr, err := resolveBindAddress("metrics", ":8081", cfg.PortFallback)
+	if err != nil {
+		return nil, err
+	}
+	healthAddr, err := resolveBindAddress("health", ":8082", cfg.PortFallback)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create manager options
 	options := ctrl.Options{
@@ -52,14 +87,45 @@ func NewControllerRuntime(cfg *config.Config) (*ControllerRuntime, error) {
 		Metrics: server.Options{
 			BindAddress: metricsAddr,
 		},
-		HealthProbeBindAddress: healthAddr,
-		LeaderElection:         cfg.EnableLeaderElection,
-		LeaderElectionID:       cfg.LeaderElectionID,
+		HealthProbeBindAddress:  healthAddr,
+		LeaderElection:          cfg.EnableLeaderElection,
+		LeaderElectionID:        cfg.LeaderElectionID,
 		LeaderElectionNamespace: cfg.LeaderElectionNamespace,
 	}
 
+	// Serve admission webhooks only when enabled, since they require TLS certificates
+	if cfg.WebhookEnabled {
+		options.WebhookServer = webhook.NewServer(webhook.Options{
+			Port:    cfg.WebhookPort,
+			CertDir: cfg.WebhookCertDir,
+		})
+	}
+
+	// Connect to the same cluster with the same settings as the informer client
+	restConfig, err := kubernetes.BuildRESTConfig(kubernetes.ConnectionOptions{
+		Kubeconfig: cfg.KubeconfigPath,
+		Context:    cfg.KubeContext,
+		QPS:        cfg.KubeQPS,
+		Burst:      cfg.KubeBurst,
+	})
+	if clientcmd.IsEmptyConfig(err) {
+		return nil, fmt.Errorf("%w: %w", ErrNoClusterConfig, err)
+	}
+	if err != nil {
+		// A kubeconfig that exists but can't be used, such as an unknown --context, is a mistake
+		return nil, fmt.Errorf("failed to load cluster configuration: %w", err)
+	}
+
+	// Run the manager as the impersonated identity, if configured
+	if cfg.ImpersonateUser != "" {
+		restConfig.Impersonate = rest.ImpersonationConfig{
+			UserName: cfg.ImpersonateUser,
+			Groups:   cfg.ImpersonateGroups,
+		}
+	}
+
 	// Create manager
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
+	mgr, err := ctrl.NewManager(restConfig, options)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create manager: %w", err)
 	}
@@ -80,6 +146,7 @@ func NewControllerRuntime(cfg *config.Config) (*ControllerRuntime, error) {
 		reconcilers:    make(map[string]reconcile.Reconciler),
 		metricsAddress: metricsAddr,
 		healthAddress:  healthAddr,
+		config:         cfg,
 	}, nil
 }
 
@@ -111,10 +178,22 @@ func (cr *ControllerRuntime) GetManager() manager.Manager {
 	return cr.manager
 }
 
+// controllerOptions returns the options shared by the registered controllers
+func (cr *ControllerRuntime) controllerOptions() crcontroller.Options {
+	return crcontroller.Options{MaxConcurrentReconciles: cr.config.MaxConcurrentReconciles}
+}
+
 // RegisterDeploymentController registers a deployment controller
 func (cr *ControllerRuntime) RegisterDeploymentController(reconciler reconcile.Reconciler) error {
+	// Start a namespace informer with the manager, so the reconciler reads namespace phases
+	// from the cache instead of the API
+	if _, err := cr.manager.GetCache().GetInformer(context.Background(), &corev1.Namespace{}); err != nil {
+		return fmt.Errorf("unable to create namespace informer: %w", err)
+	}
+
 	err := ctrl.NewControllerManagedBy(cr.manager).
 		For(&appsv1.Deployment{}).
+		WithOptions(cr.controllerOptions()).
 		Complete(reconciler)
 
 	if err != nil {
@@ -129,6 +208,7 @@ func (cr *ControllerRuntime) RegisterDeploymentController(reconciler reconcile.R
 func (cr *ControllerRuntime) RegisterPodController(reconciler reconcile.Reconciler) error {
 	err := ctrl.NewControllerManagedBy(cr.manager).
 		For(&corev1.Pod{}).
+		WithOptions(cr.controllerOptions()).
 		Complete(reconciler)
 
 	if err != nil {
@@ -139,6 +219,70 @@ func (cr *ControllerRuntime) RegisterPodController(reconciler reconcile.Reconcil
 	return nil
 }
 
+// RegisterDeploymentWebhooks registers the deployment defaulting and validating webhooks on the
+// manager's webhook server and installs the webhook configurations once the manager starts
+func (cr *ControllerRuntime) RegisterDeploymentWebhooks(defaulter admission.CustomDefaulter, validator admission.CustomValidator) error {
+	if !cr.config.WebhookEnabled {
+		return fmt.Errorf("webhook server is not enabled")
+	}
+
+	err := ctrl.NewWebhookManagedBy(cr.manager).
+		For(&appsv1.Deployment{}).
+		WithDefaulter(defaulter).
+		WithValidator(validator).
+		Complete()
+	if err != nil {
+		return fmt.Errorf("unable to create deployment webhooks: %w", err)
+	}
+
+	// Scale requests carry an autoscaling/v1 Scale, which the deployment path can't decode
+	cr.manager.GetWebhookServer().Register(deploymentScaleValidatePath,
+		admission.WithCustomValidator(cr.scheme, &autoscalingv1.Scale{}, validator))
+
+	caBundle, err := LoadCABundle(cr.config.WebhookCertDir)
+	if err != nil {
+		return fmt.Errorf("unable to load webhook CA bundle: %w", err)
+	}
+
+	// Use an uncached client so installing the configuration doesn't start an informer for it
+	installClient, err := client.New(cr.manager.GetConfig(), client.Options{Scheme: cr.scheme})
+	if err != nil {
+		return fmt.Errorf("unable to create webhook installation client: %w", err)
+	}
+
+	return cr.manager.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		return InstallWebhookConfigurations(ctx, installClient,
+			cr.config.WebhookServiceName, cr.config.WebhookServiceNamespace, webhookServicePort, caBundle)
+	}))
+}
+
+// AddHealthCheck registers a named liveness check served under the manager's /healthz endpoint
+func (cr *ControllerRuntime) AddHealthCheck(name string, check healthz.Checker) error {
+	if err := cr.manager.AddHealthzCheck(name, check); err != nil {
+		return fmt.Errorf("unable to add health check %s: %w", name, err)
+	}
+	return nil
+}
+
+// AddReadyCheck registers a named readiness check served under the manager's /readyz endpoint
+func (cr *ControllerRuntime) AddReadyCheck(name string, check healthz.Checker) error {
+	if err := cr.manager.AddReadyzCheck(name, check); err != nil {
+		return fmt.Errorf("unable to add ready check %s: %w", name, err)
+	}
+	return nil
+}
+
+// IsLeader reports whether this instance is the elected leader.
+// When leader election is disabled the manager is always considered the leader.
+func (cr *ControllerRuntime) IsLeader() bool {
+	select {
+	case <-cr.manager.Elected():
+		return true
+	default:
+		return false
+	}
+}
+
 // GetMetricsEndpoint returns the metrics endpoint address
 func (cr *ControllerRuntime) GetMetricsEndpoint() string {
 	return cr.metricsAddress
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s-controller/internal/infrastructure/config"
+)
+
+func TestNewControllerRuntimeWithoutClusterConfig(t *testing.T) {
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "missing"))
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+
+	// Don't depend on the default manager ports being free on the test machine
+	cfg := config.Default()
+	cfg.PortFallback = true
+
+	_, err := NewControllerRuntime(cfg)
+	if !errors.Is(err, ErrNoClusterConfig) {
+		t.Fatalf("expected ErrNoClusterConfig, got %v", err)
+	}
+}
+
+func TestNewControllerRuntimeWithInvalidKubeconfig(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "config")
+	if err := os.WriteFile(valid, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: dev
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: dev
+  context:
+    cluster: dev
+current-context: dev
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	unparsable := filepath.Join(dir, "broken")
+	if err := os.WriteFile(unparsable, []byte("clusters: [\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]func(cfg *config.Config){
+		"unknown context":       func(cfg *config.Config) { cfg.KubeconfigPath, cfg.KubeContext = valid, "missing" },
+		"unparsable kubeconfig": func(cfg *config.Config) { cfg.KubeconfigPath = unparsable },
+	}
+	for name, configure := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.PortFallback = true
+			configure(cfg)
+			_, err := NewControllerRuntime(cfg)
+			if err == nil || errors.Is(err, ErrNoClusterConfig) {
+				t.Fatalf("expected a startup error other than ErrNoClusterConfig, got %v", err)
+			}
+		})
+	}
+}
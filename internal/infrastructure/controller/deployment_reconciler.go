@@ -2,73 +2,259 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"k8s-controller/internal/domain"
+	"k8s-controller/internal/infrastructure/kubernetes"
+	"k8s-controller/internal/infrastructure/metrics"
+	"k8s-controller/internal/infrastructure/tracing"
+)
+
+// Reconcile outcomes recorded in the reconcile metrics
+const (
+	reconcileSuccess  = "success"
+	reconcileError    = "error"
+	reconcileRequeue  = "requeue"
+	reconcileNotFound = "not-found"
+	reconcileSkipped  = "skipped"
+	// reconcilePermanentError is a failure that is not retried with the controller-runtime backoff
+	reconcilePermanentError = "permanent-error"
+
+	// deploymentControllerName labels the deployment reconciler metrics
+	deploymentControllerName = "deployment"
+)
+
+// Event reasons recorded by the replica floor policy and for permanent API errors
+const (
+	reasonReplicaFloorEnforced = "ReplicaFloorEnforced"
+	reasonInvalidReplicaFloor  = "InvalidReplicaFloor"
+	reasonPermanentAPIError    = "PermanentAPIError"
 )
 
 // DeploymentReconciler reconciles Deployment objects
 type DeploymentReconciler struct {
 	client client.Client
 	scheme *runtime.Scheme
-	// Add a reference to the domain service if needed
+	// resourceService processes reconciled deployments; nil only gets, logs and enforces policies
 	resourceService domain.ResourceService
+	// recorder records events for policy actions, nil disables events
+	recorder record.EventRecorder
+	// namespaces is the allow-list of namespaces to reconcile; empty reconciles all namespaces
+	namespaces map[string]bool
+	// progress tracks running and completed reconciles for the liveness check
+	progress *ReconcileProgress
+	// transientRequeue is the delay before retrying after a transient API error
+	transientRequeue time.Duration
+	// permanentRequeue is the delay before retrying after a permanent API error; 0 does not retry
+	permanentRequeue time.Duration
 }
 
-// NewDeploymentReconciler creates a new deployment reconciler
+// NewDeploymentReconciler creates a new deployment reconciler. The resource service may be nil,
+// e.g. in tests, in which case deployments are fetched and logged but not processed.
 func NewDeploymentReconciler(client client.Client, scheme *runtime.Scheme, resourceService domain.ResourceService) *DeploymentReconciler {
 	return &DeploymentReconciler{
-		client:          client,
-		scheme:          scheme,
-		resourceService: resourceService,
+		client:           client,
+		scheme:           scheme,
+		resourceService:  resourceService,
+		progress:         NewReconcileProgress(),
+		transientRequeue: DefaultTransientRequeueDelay,
+		permanentRequeue: DefaultPermanentRequeueDelay,
+	}
+}
+
+// Progress returns the tracker of the reconciler's running and completed reconciles
+func (r *DeploymentReconciler) Progress() *ReconcileProgress {
+	return r.progress
+}
+
+// SetEventRecorder sets the recorder used to emit events when the replica floor is enforced
+func (r *DeploymentReconciler) SetEventRecorder(recorder record.EventRecorder) {
+	r.recorder = recorder
+}
+
+// SetRequeueDelays sets how long to wait before retrying a reconcile that failed with an API
+// error. Transient errors (timeouts, throttling, an unavailable API server) are retried after
+// transient, or after the server's Retry-After when longer. Permanent errors (forbidden,
+// unauthorized, invalid) record a warning event and are retried after permanent, or only on the
+// next change to the deployment when permanent is 0. A transient delay of 0 keeps the default.
+func (r *DeploymentReconciler) SetRequeueDelays(transient, permanent time.Duration) {
+	if transient > 0 {
+		r.transientRequeue = transient
+	}
+	if permanent >= 0 {
+		r.permanentRequeue = permanent
 	}
 }
 
-// Reconcile implements the reconcile.Reconciler interface
+// SetNamespaces restricts the reconciler to the given namespaces, even when the manager cache
+// watches more. An empty list reconciles every namespace.
+func (r *DeploymentReconciler) SetNamespaces(namespaces []string) {
+	r.namespaces = nil
+	if len(namespaces) == 0 {
+		return
+	}
+	r.namespaces = make(map[string]bool, len(namespaces))
+	for _, namespace := range namespaces {
+		r.namespaces[namespace] = true
+	}
+}
+
+// inScope reports whether deployments in the namespace are reconciled
+func (r *DeploymentReconciler) inScope(namespace string) bool {
+	return len(r.namespaces) == 0 || r.namespaces[namespace]
+}
+
+// Reconcile implements the reconcile.Reconciler interface and records the outcome and duration
 func (r *DeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.Start(ctx, "DeploymentReconciler.Reconcile", "Deployment", req.Namespace, req.Name)
+
+	start := time.Now()
+	r.progress.Started()
+	result, outcome, err := r.reconcile(ctx, req)
+	r.progress.Completed()
+
+	metrics.ReconcileDuration.WithLabelValues(deploymentControllerName).Observe(time.Since(start).Seconds())
+	metrics.ReconcileTotal.WithLabelValues(deploymentControllerName, outcome).Inc()
+
+	span.SetAttributes(attribute.String("k8s_controller.reconcile.outcome", outcome))
+	tracing.End(span, err)
+
+	return result, err
+}
+
+// reconcile processes the deployment and reports which outcome to record
+func (r *DeploymentReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, string, error) {
+	if !r.inScope(req.Namespace) {
+		slog.Debug("Skipping deployment outside the reconciled namespaces", "name", req.Name, "namespace", req.Namespace)
+		return ctrl.Result{}, reconcileSkipped, nil
+	}
+
+	// Updates in a namespace that is being deleted only fail, so leave its deployments alone
+	if r.namespaceTerminating(ctx, req.Namespace) {
+		slog.Debug("Skipping deployment in terminating namespace", "name", req.Name, "namespace", req.Namespace)
+		return ctrl.Result{}, reconcileSkipped, nil
+	}
+
 	// Get the Deployment object
 	var deployment appsv1.Deployment
 	if err := r.client.Get(ctx, req.NamespacedName, &deployment); err != nil {
 		if errors.IsNotFound(err) {
 			// The object was deleted
 			slog.Info("Deployment was deleted", "name", req.Name, "namespace", req.Namespace)
-			return ctrl.Result{}, nil
+			return ctrl.Result{}, reconcileNotFound, nil
 		}
 		slog.Error("Failed to get Deployment", "name", req.Name, "namespace", req.Namespace, "error", err)
-		return ctrl.Result{}, err
+		deployment.Name, deployment.Namespace = req.Name, req.Namespace
+		return r.requeueOnAPIError(&deployment, "get deployment", err)
 	}
 
-	// Convert k8s deployment to domain deployment
-	domainDeployment := domain.Deployment{
-		Name:      deployment.Name,
-		Namespace: deployment.Namespace,
-		Replicas:  *deployment.Spec.Replicas,
-		Status: domain.DeploymentStatus{
-			AvailableReplicas:   deployment.Status.AvailableReplicas,
-			UnavailableReplicas: deployment.Status.UnavailableReplicas,
-			ReadyReplicas:       deployment.Status.ReadyReplicas,
-		},
-		Labels:    deployment.Labels,
-		CreatedAt: deployment.CreationTimestamp.Time,
+	// Scale the deployment back up if it dropped below its replica floor
+	if err := r.enforceReplicaFloor(ctx, &deployment); err != nil {
+		slog.Error("Failed to enforce replica floor", "name", deployment.Name, "namespace", deployment.Namespace, "error", err)
+		return r.requeueOnAPIError(&deployment, "enforce replica floor", err)
 	}
 
+	if r.resourceService == nil {
+		slog.Debug("No resource service, skipping deployment processing", "name", deployment.Name, "namespace", deployment.Namespace)
+		return ctrl.Result{}, reconcileSuccess, nil
+	}
+
+	// Convert k8s deployment to domain deployment
+	domainDeployment := kubernetes.ToDomainDeployment(&deployment)
+
 	// Process the domain deployment using the resource service
-	if r.resourceService != nil {
-		if err := r.resourceService.ProcessDeployment(ctx, domainDeployment); err != nil {
-			slog.Error("Failed to process deployment", "name", deployment.Name, "error", err)
-			// Requeue after 30 seconds
-			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	if err := r.resourceService.ProcessDeployment(ctx, domainDeployment); err != nil {
+		slog.Error("Failed to process deployment", "name", deployment.Name, "error", err)
+		// Requeue after 30 seconds
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, reconcileRequeue, nil
+	}
+
+	return ctrl.Result{}, reconcileSuccess, nil
+}
+
+// requeueOnAPIError returns the reconcile result for an API error by its class. Transient errors
+// are requeued after the transient delay and permanent ones record a warning event and are
+// requeued after the permanent delay, if any. Other errors are returned for the
+// controller-runtime backoff.
+func (r *DeploymentReconciler) requeueOnAPIError(deployment *appsv1.Deployment, action string, err error) (ctrl.Result, string, error) {
+	switch classifyAPIError(err) {
+	case apiErrorTransient:
+		delay := transientDelay(err, r.transientRequeue)
+		slog.Warn("Requeueing deployment after transient API error", "name", deployment.Name, "namespace", deployment.Namespace, "after", delay)
+		return ctrl.Result{RequeueAfter: delay}, reconcileRequeue, nil
+	case apiErrorPermanent:
+		r.recordEvent(deployment, corev1.EventTypeWarning, reasonPermanentAPIError, "Failed to %s: %v", action, err)
+		return ctrl.Result{RequeueAfter: r.permanentRequeue}, reconcilePermanentError, nil
+	default:
+		return ctrl.Result{}, reconcileError, err
+	}
+}
+
+// namespaceTerminating reports whether the namespace is being deleted. The namespace is read from
+// the manager cache; when it can't be read the deployment is reconciled as usual.
+func (r *DeploymentReconciler) namespaceTerminating(ctx context.Context, name string) bool {
+	var namespace corev1.Namespace
+	if err := r.client.Get(ctx, client.ObjectKey{Name: name}, &namespace); err != nil {
+		if !errors.IsNotFound(err) {
+			slog.Debug("Failed to get namespace phase", "namespace", name, "error", err)
 		}
+		return false
+	}
+	return namespace.Status.Phase == corev1.NamespaceTerminating || namespace.DeletionTimestamp != nil
+}
+
+// enforceReplicaFloor patches spec.replicas up to the floor from domain.MinReplicasAnnotation.
+// Deployments without the annotation are left alone; an invalid value is reported but not retried.
+func (r *DeploymentReconciler) enforceReplicaFloor(ctx context.Context, deployment *appsv1.Deployment) error {
+	value, ok := deployment.Annotations[domain.MinReplicasAnnotation]
+	if !ok {
+		return nil
 	}
 
-	return ctrl.Result{}, nil
+	floor, err := domain.ParseReplicaFloor(value)
+	if err != nil {
+		slog.Warn("Ignoring invalid replica floor", "name", deployment.Name, "namespace", deployment.Namespace, "error", err)
+		r.recordEvent(deployment, corev1.EventTypeWarning, reasonInvalidReplicaFloor, "Ignoring %s: %v", domain.MinReplicasAnnotation, err)
+		return nil
+	}
+
+	// Unset replicas default to 1
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if replicas >= floor {
+		return nil
+	}
+
+	patch := client.MergeFrom(deployment.DeepCopy())
+	deployment.Spec.Replicas = &floor
+	if err := r.client.Patch(ctx, deployment, patch); err != nil {
+		return fmt.Errorf("failed to scale deployment to replica floor: %w", err)
+	}
+
+	slog.Info("Scaled deployment up to replica floor", "name", deployment.Name, "namespace", deployment.Namespace, "from", replicas, "to", floor)
+	r.recordEvent(deployment, corev1.EventTypeNormal, reasonReplicaFloorEnforced, "Scaled replicas from %d to floor %d", replicas, floor)
+	return nil
+}
+
+// recordEvent records an event on the deployment when a recorder is set
+func (r *DeploymentReconciler) recordEvent(deployment *appsv1.Deployment, eventType, reason, messageFmt string, args ...interface{}) {
+	if r.recorder == nil {
+		return
+	}
+	r.recorder.Eventf(deployment, eventType, reason, messageFmt, args...)
 }
 
 // SetupWithManager sets up the controller with the Manager
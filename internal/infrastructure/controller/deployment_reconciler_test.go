@@ -0,0 +1,265 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"k8s-controller/internal/domain"
+	"k8s-controller/internal/infrastructure/metrics"
+)
+
+func TestReconcileRecordsOutcome(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	).Build()
+	reconciler := NewDeploymentReconciler(fakeClient, scheme, nil)
+
+	success := metrics.ReconcileTotal.WithLabelValues(deploymentControllerName, reconcileSuccess)
+	notFound := metrics.ReconcileTotal.WithLabelValues(deploymentControllerName, reconcileNotFound)
+	successBefore, notFoundBefore := testutil.ToFloat64(success), testutil.ToFloat64(notFound)
+
+	for _, name := range []string{"web", "missing"} {
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: name}}
+		if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile(%s) failed: %v", name, err)
+		}
+	}
+
+	if got := testutil.ToFloat64(success) - successBefore; got != 1 {
+		t.Errorf("expected 1 success, got %v", got)
+	}
+	if got := testutil.ToFloat64(notFound) - notFoundBefore; got != 1 {
+		t.Errorf("expected 1 not-found, got %v", got)
+	}
+	if count := testutil.CollectAndCount(metrics.ReconcileDuration); count != 1 {
+		t.Errorf("expected one duration series, got %d", count)
+	}
+}
+
+func TestReconcileSkipsNamespacesOutOfScope(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	belowFloor := func(namespace string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: namespace, Annotations: map[string]string{domain.MinReplicasAnnotation: "2"}},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(1))},
+		}
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		belowFloor("team-a"),
+		belowFloor("team-b"),
+	).Build()
+	reconciler := NewDeploymentReconciler(fakeClient, scheme, nil)
+	reconciler.SetNamespaces([]string{"team-a"})
+
+	skipped := metrics.ReconcileTotal.WithLabelValues(deploymentControllerName, reconcileSkipped)
+	skippedBefore := testutil.ToFloat64(skipped)
+
+	want := map[string]int32{"team-a": 2, "team-b": 1}
+	for namespace, replicas := range want {
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "web"}}
+		if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile(%s) failed: %v", namespace, err)
+		}
+
+		var deployment appsv1.Deployment
+		if err := fakeClient.Get(context.Background(), req.NamespacedName, &deployment); err != nil {
+			t.Fatalf("failed to get %s/web: %v", namespace, err)
+		}
+		if got := *deployment.Spec.Replicas; got != replicas {
+			t.Errorf("%s: replicas = %d, want %d", namespace, got, replicas)
+		}
+	}
+
+	if got := testutil.ToFloat64(skipped) - skippedBefore; got != 1 {
+		t.Errorf("expected 1 skipped reconcile, got %v", got)
+	}
+}
+
+func TestReconcileSkipsTerminatingNamespaces(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "teardown"}, Status: corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating}},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "teardown", Annotations: map[string]string{domain.MinReplicasAnnotation: "2"}},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(1))},
+		},
+	).Build()
+	reconciler := NewDeploymentReconciler(fakeClient, scheme, nil)
+
+	skipped := metrics.ReconcileTotal.WithLabelValues(deploymentControllerName, reconcileSkipped)
+	skippedBefore := testutil.ToFloat64(skipped)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "teardown", Name: "web"}}
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	var deployment appsv1.Deployment
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &deployment); err != nil {
+		t.Fatalf("failed to get teardown/web: %v", err)
+	}
+	if got := *deployment.Spec.Replicas; got != 1 {
+		t.Errorf("expected the deployment to be left alone, got %d replicas", got)
+	}
+	if got := testutil.ToFloat64(skipped) - skippedBefore; got != 1 {
+		t.Errorf("expected 1 skipped reconcile, got %v", got)
+	}
+}
+
+func TestReconcileEnforcesReplicaFloor(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	withFloor := func(name, floor string, replicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Annotations: map[string]string{domain.MinReplicasAnnotation: floor}},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(replicas)},
+		}
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		withFloor("below", "3", 1),
+		withFloor("above", "2", 5),
+		withFloor("invalid", "many", 1),
+	).Build()
+	recorder := record.NewFakeRecorder(10)
+	reconciler := NewDeploymentReconciler(fakeClient, scheme, nil)
+	reconciler.SetEventRecorder(recorder)
+
+	want := map[string]int32{"below": 3, "above": 5, "invalid": 1}
+	for name, replicas := range want {
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: name}}
+		if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile(%s) failed: %v", name, err)
+		}
+
+		var deployment appsv1.Deployment
+		if err := fakeClient.Get(context.Background(), req.NamespacedName, &deployment); err != nil {
+			t.Fatalf("failed to get %s: %v", name, err)
+		}
+		if got := *deployment.Spec.Replicas; got != replicas {
+			t.Errorf("%s: replicas = %d, want %d", name, got, replicas)
+		}
+	}
+
+	close(recorder.Events)
+	var events []string
+	for event := range recorder.Events {
+		events = append(events, event)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %v", events)
+	}
+	for _, reason := range []string{reasonReplicaFloorEnforced, reasonInvalidReplicaFloor} {
+		if !strings.Contains(strings.Join(events, "\n"), reason) {
+			t.Errorf("expected an event with reason %s, got %v", reason, events)
+		}
+	}
+}
+
+func TestReconcileRequeuesByAPIErrorClass(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	deploymentsResource := schema.GroupResource{Group: "apps", Resource: "deployments"}
+
+	tests := []struct {
+		name    string
+		err     error
+		result  ctrl.Result
+		wantErr bool
+		event   bool
+	}{
+		{name: "timeout", err: apierrors.NewTimeoutError("slow", 0), result: ctrl.Result{RequeueAfter: 2 * time.Second}},
+		{name: "throttled", err: apierrors.NewTooManyRequests("slow down", 10), result: ctrl.Result{RequeueAfter: 10 * time.Second}},
+		{name: "forbidden", err: apierrors.NewForbidden(deploymentsResource, "web", errors.New("no RBAC")), result: ctrl.Result{}, event: true},
+		{name: "other", err: apierrors.NewInternalError(errors.New("boom")), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if _, ok := obj.(*appsv1.Deployment); ok {
+						return tt.err
+					}
+					return c.Get(ctx, key, obj, opts...)
+				},
+			}).Build()
+			recorder := record.NewFakeRecorder(10)
+			reconciler := NewDeploymentReconciler(fakeClient, scheme, nil)
+			reconciler.SetEventRecorder(recorder)
+			reconciler.SetRequeueDelays(2*time.Second, 0)
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "web"}}
+			result, err := reconciler.Reconcile(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if result != tt.result {
+				t.Errorf("expected result %+v, got %+v", tt.result, result)
+			}
+			if got := len(recorder.Events) > 0; got != tt.event {
+				t.Errorf("expected an event: %v, got %v", tt.event, got)
+			}
+			if tt.event && !strings.Contains(<-recorder.Events, reasonPermanentAPIError) {
+				t.Errorf("expected a %s event", reasonPermanentAPIError)
+			}
+		})
+	}
+}
+
+func TestReconcileWithoutResourceService(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	).Build()
+	reconciler := NewDeploymentReconciler(fakeClient, scheme, nil)
+
+	success := metrics.ReconcileTotal.WithLabelValues(deploymentControllerName, reconcileSuccess)
+	before := testutil.ToFloat64(success)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "web"}}
+	result, err := reconciler.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Reconcile failed without a resource service: %v", err)
+	}
+	if result != (ctrl.Result{}) {
+		t.Errorf("expected no requeue, got %+v", result)
+	}
+	if got := testutil.ToFloat64(success) - before; got != 1 {
+		t.Errorf("expected the reconcile to count as success, got %v", got)
+	}
+}
@@ -0,0 +1,319 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// MutatingWebhookConfigurationName is the name of the registered mutating webhook configuration
+	MutatingWebhookConfigurationName = "k8s-controller-mutating-webhook"
+
+	// ValidatingWebhookConfigurationName is the name of the registered validating webhook configuration
+	ValidatingWebhookConfigurationName = "k8s-controller-validating-webhook"
+
+	// deploymentMutatePath is the path controller-runtime serves the deployment defaulter on
+	deploymentMutatePath = "/mutate-apps-v1-deployment"
+
+	// deploymentValidatePath is the path controller-runtime serves the deployment validator on
+	deploymentValidatePath = "/validate-apps-v1-deployment"
+
+	// deploymentScaleValidatePath is the path the validator is served on for the deployment scale subresource
+	deploymentScaleValidatePath = "/validate-apps-v1-deployment-scale"
+
+	// webhookServicePort is the port the webhook service exposes to the API server
+	webhookServicePort = 443
+)
+
+// DeploymentDefaulter injects default labels into deployments that do not set them
+type DeploymentDefaulter struct {
+	labels map[string]string
+}
+
+// NewDeploymentDefaulter creates a defaulter that adds the given labels when absent
+func NewDeploymentDefaulter(labels map[string]string) *DeploymentDefaulter {
+	return &DeploymentDefaulter{
+		labels: labels,
+	}
+}
+
+// Default implements admission.CustomDefaulter
+func (d *DeploymentDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	deployment, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		return fmt.Errorf("expected a Deployment but got %T", obj)
+	}
+
+	for key, value := range d.labels {
+		if _, exists := deployment.Labels[key]; exists {
+			continue
+		}
+		if deployment.Labels == nil {
+			deployment.Labels = make(map[string]string)
+		}
+		deployment.Labels[key] = value
+		slog.Debug("Injected default label", "name", deployment.Name, "namespace", deployment.Namespace, "label", key)
+	}
+
+	return nil
+}
+
+// DeploymentValidator rejects deployments below the minimum replica count or missing required labels.
+// Scale requests for the deployment scale subresource are checked against the minimum replica count.
+type DeploymentValidator struct {
+	minReplicas    int32
+	requiredLabels []string
+}
+
+// NewDeploymentValidator creates a validator with the given rules
+func NewDeploymentValidator(minReplicas int32, requiredLabels []string) *DeploymentValidator {
+	return &DeploymentValidator{
+		minReplicas:    minReplicas,
+		requiredLabels: requiredLabels,
+	}
+}
+
+// ValidateCreate implements admission.CustomValidator
+func (v *DeploymentValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(obj)
+}
+
+// ValidateUpdate implements admission.CustomValidator
+func (v *DeploymentValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(newObj)
+}
+
+// ValidateDelete implements admission.CustomValidator. Deletes are always allowed.
+func (v *DeploymentValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks the deployment against the configured rules and returns all violations together
+func (v *DeploymentValidator) validate(obj runtime.Object) error {
+	if scale, ok := obj.(*autoscalingv1.Scale); ok {
+		return v.validateScale(scale)
+	}
+
+	deployment, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		return fmt.Errorf("expected a Deployment or Scale but got %T", obj)
+	}
+
+	var errs field.ErrorList
+
+	// The API server defaults unset replicas to 1
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if replicas < v.minReplicas {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "replicas"), replicas,
+			fmt.Sprintf("must be at least %d", v.minReplicas)))
+	}
+
+	for _, key := range v.requiredLabels {
+		if _, ok := deployment.Labels[key]; !ok {
+			errs = append(errs, field.Required(field.NewPath("metadata", "labels").Key(key),
+				fmt.Sprintf("label %q is required", key)))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	slog.Info("Rejected deployment", "name", deployment.Name, "namespace", deployment.Namespace, "errors", errs.ToAggregate())
+	return apierrors.NewInvalid(appsv1.SchemeGroupVersion.WithKind("Deployment").GroupKind(), deployment.Name, errs)
+}
+
+// validateScale checks a scale subresource request against the minimum replica count
+func (v *DeploymentValidator) validateScale(scale *autoscalingv1.Scale) error {
+	if scale.Spec.Replicas >= v.minReplicas {
+		return nil
+	}
+
+	errs := field.ErrorList{field.Invalid(field.NewPath("spec", "replicas"), scale.Spec.Replicas,
+		fmt.Sprintf("must be at least %d", v.minReplicas))}
+	slog.Info("Rejected deployment scale", "name", scale.Name, "namespace", scale.Namespace, "errors", errs.ToAggregate())
+	return apierrors.NewInvalid(autoscalingv1.SchemeGroupVersion.WithKind("Scale").GroupKind(), scale.Name, errs)
+}
+
+// NewMutatingWebhookConfiguration builds the configuration that routes deployment
+// create and update requests to the webhook service
+func NewMutatingWebhookConfiguration(serviceName, serviceNamespace string, port int32, caBundle []byte) *admissionregistrationv1.MutatingWebhookConfiguration {
+	config := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: MutatingWebhookConfigurationName},
+	}
+	setMutatingWebhooks(config, serviceName, serviceNamespace, port, caBundle)
+	return config
+}
+
+// setMutatingWebhooks sets the deployment webhook on the configuration
+func setMutatingWebhooks(config *admissionregistrationv1.MutatingWebhookConfiguration, serviceName, serviceNamespace string, port int32, caBundle []byte) {
+	failurePolicy := admissionregistrationv1.Fail
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+
+	config.Webhooks = []admissionregistrationv1.MutatingWebhook{{
+		Name:                    "mdeployment.k8s-controller.io",
+		ClientConfig:            webhookClientConfig(serviceName, serviceNamespace, deploymentMutatePath, port, caBundle),
+		Rules:                   deploymentWebhookRules(),
+		NamespaceSelector:       webhookNamespaceSelector(serviceNamespace),
+		FailurePolicy:           &failurePolicy,
+		SideEffects:             &sideEffects,
+		AdmissionReviewVersions: []string{"v1"},
+	}}
+}
+
+// NewValidatingWebhookConfiguration builds the configuration that routes deployment
+// create, update and scale requests to the validating webhook
+func NewValidatingWebhookConfiguration(serviceName, serviceNamespace string, port int32, caBundle []byte) *admissionregistrationv1.ValidatingWebhookConfiguration {
+	config := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: ValidatingWebhookConfigurationName},
+	}
+	setValidatingWebhooks(config, serviceName, serviceNamespace, port, caBundle)
+	return config
+}
+
+// setValidatingWebhooks sets the deployment and deployment scale webhooks on the configuration
+func setValidatingWebhooks(config *admissionregistrationv1.ValidatingWebhookConfiguration, serviceName, serviceNamespace string, port int32, caBundle []byte) {
+	failurePolicy := admissionregistrationv1.Fail
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+
+	config.Webhooks = []admissionregistrationv1.ValidatingWebhook{
+		{
+			Name:                    "vdeployment.k8s-controller.io",
+			ClientConfig:            webhookClientConfig(serviceName, serviceNamespace, deploymentValidatePath, port, caBundle),
+			Rules:                   deploymentWebhookRules(),
+			NamespaceSelector:       webhookNamespaceSelector(serviceNamespace),
+			FailurePolicy:           &failurePolicy,
+			SideEffects:             &sideEffects,
+			AdmissionReviewVersions: []string{"v1"},
+		},
+		{
+			Name:                    "vdeploymentscale.k8s-controller.io",
+			ClientConfig:            webhookClientConfig(serviceName, serviceNamespace, deploymentScaleValidatePath, port, caBundle),
+			Rules:                   deploymentScaleWebhookRules(),
+			NamespaceSelector:       webhookNamespaceSelector(serviceNamespace),
+			FailurePolicy:           &failurePolicy,
+			SideEffects:             &sideEffects,
+			AdmissionReviewVersions: []string{"v1"},
+		},
+	}
+}
+
+// webhookClientConfig points the API server at the webhook service path
+func webhookClientConfig(serviceName, serviceNamespace, path string, port int32, caBundle []byte) admissionregistrationv1.WebhookClientConfig {
+	return admissionregistrationv1.WebhookClientConfig{
+		Service: &admissionregistrationv1.ServiceReference{
+			Name:      serviceName,
+			Namespace: serviceNamespace,
+			Path:      &path,
+			Port:      &port,
+		},
+		CABundle: caBundle,
+	}
+}
+
+// webhookNamespaceSelector excludes kube-system and the namespace of the webhook service.
+// With the Fail policy an unavailable webhook would otherwise block the deployments that
+// bring it back, including its own.
+func webhookNamespaceSelector(serviceNamespace string) *metav1.LabelSelector {
+	excluded := []string{metav1.NamespaceSystem}
+	if serviceNamespace != metav1.NamespaceSystem {
+		excluded = append(excluded, serviceNamespace)
+	}
+	return &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      corev1.LabelMetadataName,
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   excluded,
+		}},
+	}
+}
+
+// deploymentWebhookRules matches deployment create and update requests
+func deploymentWebhookRules() []admissionregistrationv1.RuleWithOperations {
+	return []admissionregistrationv1.RuleWithOperations{{
+		Operations: []admissionregistrationv1.OperationType{
+			admissionregistrationv1.Create,
+			admissionregistrationv1.Update,
+		},
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{"apps"},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"deployments"},
+		},
+	}}
+}
+
+// deploymentScaleWebhookRules matches updates of the deployment scale subresource, used by
+// kubectl scale and autoscalers
+func deploymentScaleWebhookRules() []admissionregistrationv1.RuleWithOperations {
+	return []admissionregistrationv1.RuleWithOperations{{
+		Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Update},
+		Rule: admissionregistrationv1.Rule{
+			APIGroups:   []string{"apps"},
+			APIVersions: []string{"v1"},
+			Resources:   []string{"deployments/scale"},
+		},
+	}}
+}
+
+// InstallWebhookConfigurations creates or updates the mutating and validating webhook configurations
+func InstallWebhookConfigurations(ctx context.Context, c client.Client, serviceName, serviceNamespace string, port int32, caBundle []byte) error {
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: MutatingWebhookConfigurationName},
+	}
+	result, err := controllerutil.CreateOrUpdate(ctx, c, mutating, func() error {
+		setMutatingWebhooks(mutating, serviceName, serviceNamespace, port, caBundle)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to install mutating webhook configuration: %w", err)
+	}
+	slog.Info("Mutating webhook configuration installed", "name", MutatingWebhookConfigurationName, "result", result)
+
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: ValidatingWebhookConfigurationName},
+	}
+	result, err = controllerutil.CreateOrUpdate(ctx, c, validating, func() error {
+		setValidatingWebhooks(validating, serviceName, serviceNamespace, port, caBundle)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to install validating webhook configuration: %w", err)
+	}
+	slog.Info("Validating webhook configuration installed", "name", ValidatingWebhookConfigurationName, "result", result)
+
+	return nil
+}
+
+// LoadCABundle reads the CA used to sign the webhook serving certificate from the cert dir.
+// It prefers ca.crt and falls back to tls.crt for self-signed certificates.
+func LoadCABundle(certDir string) ([]byte, error) {
+	for _, name := range []string{"ca.crt", "tls.crt"} {
+		data, err := os.ReadFile(filepath.Join(certDir, name))
+		if err == nil {
+			return data, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("no ca.crt or tls.crt found in %s", certDir)
+}
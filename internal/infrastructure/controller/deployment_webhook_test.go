@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestDeploymentDefaulterInjectsMissingLabels(t *testing.T) {
+	defaulter := NewDeploymentDefaulter(map[string]string{"managed-by": "k8s-controller"})
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web"}}
+	if err := defaulter.Default(context.Background(), deployment); err != nil {
+		t.Fatalf("Default failed: %v", err)
+	}
+	if got := deployment.Labels["managed-by"]; got != "k8s-controller" {
+		t.Errorf("expected managed-by=k8s-controller, got %q", got)
+	}
+
+	// Existing values must be preserved
+	deployment = &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:   "web",
+		Labels: map[string]string{"managed-by": "helm"},
+	}}
+	if err := defaulter.Default(context.Background(), deployment); err != nil {
+		t.Fatalf("Default failed: %v", err)
+	}
+	if got := deployment.Labels["managed-by"]; got != "helm" {
+		t.Errorf("expected existing label to be kept, got %q", got)
+	}
+
+	if err := defaulter.Default(context.Background(), &appsv1.StatefulSet{}); err == nil {
+		t.Error("expected an error for a non-deployment object")
+	}
+}
+
+func TestNewMutatingWebhookConfiguration(t *testing.T) {
+	config := NewMutatingWebhookConfiguration("k8s-controller", "k8s-controller-system", 443, []byte("ca"))
+
+	if len(config.Webhooks) != 1 {
+		t.Fatalf("expected one webhook, got %d", len(config.Webhooks))
+	}
+	service := config.Webhooks[0].ClientConfig.Service
+	if service.Name != "k8s-controller" || service.Namespace != "k8s-controller-system" {
+		t.Errorf("unexpected service reference %s/%s", service.Namespace, service.Name)
+	}
+	if *service.Path != deploymentMutatePath {
+		t.Errorf("expected path %s, got %s", deploymentMutatePath, *service.Path)
+	}
+}
+
+func TestWebhookConfigurationsSkipSystemNamespaces(t *testing.T) {
+	mutating := NewMutatingWebhookConfiguration("k8s-controller", "k8s-controller-system", 443, []byte("ca"))
+	validating := NewValidatingWebhookConfiguration("k8s-controller", "k8s-controller-system", 443, []byte("ca"))
+
+	for name, selector := range map[string]*metav1.LabelSelector{
+		"mutating":   mutating.Webhooks[0].NamespaceSelector,
+		"validating": validating.Webhooks[0].NamespaceSelector,
+	} {
+		parsed, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			t.Fatalf("%s: invalid namespace selector: %v", name, err)
+		}
+		for namespace, want := range map[string]bool{"default": true, "kube-system": false, "k8s-controller-system": false} {
+			if got := parsed.Matches(labels.Set{corev1.LabelMetadataName: namespace}); got != want {
+				t.Errorf("%s: expected namespace %s matched=%v, got %v", name, namespace, want, got)
+			}
+		}
+	}
+}
+
+func TestDeploymentValidator(t *testing.T) {
+	validator := NewDeploymentValidator(1, []string{"managed-by"})
+	zero := int32(0)
+
+	tests := []struct {
+		name       string
+		deployment *appsv1.Deployment
+		wantErr    []string
+	}{
+		{
+			name: "valid with defaulted replicas",
+			deployment: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+				Name: "web", Labels: map[string]string{"managed-by": "k8s-controller"},
+			}},
+		},
+		{
+			name: "zero replicas",
+			deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Labels: map[string]string{"managed-by": "k8s-controller"}},
+				Spec:       appsv1.DeploymentSpec{Replicas: &zero},
+			},
+			wantErr: []string{"spec.replicas", "must be at least 1"},
+		},
+		{
+			name: "missing label and zero replicas",
+			deployment: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "web"},
+				Spec:       appsv1.DeploymentSpec{Replicas: &zero},
+			},
+			wantErr: []string{"spec.replicas", "metadata.labels[managed-by]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validator.ValidateCreate(context.Background(), tt.deployment)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("expected deployment to be allowed, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected deployment to be rejected")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected error to contain %q, got %q", want, err.Error())
+				}
+			}
+		})
+	}
+
+	if _, err := validator.ValidateDelete(context.Background(), &appsv1.Deployment{}); err != nil {
+		t.Errorf("expected deletes to be allowed, got %v", err)
+	}
+}
+
+func TestDeploymentValidatorChecksScaleRequests(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := autoscalingv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	webhook := admission.WithCustomValidator(scheme, &autoscalingv1.Scale{}, NewDeploymentValidator(2, []string{"managed-by"}))
+
+	scaleRequest := func(replicas int32) admission.Request {
+		raw, err := json.Marshal(&autoscalingv1.Scale{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling/v1", Kind: "Scale"},
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       autoscalingv1.ScaleSpec{Replicas: replicas},
+		})
+		if err != nil {
+			t.Fatalf("failed to encode scale: %v", err)
+		}
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Update,
+			Object:    runtime.RawExtension{Raw: raw},
+			OldObject: runtime.RawExtension{Raw: raw},
+		}}
+	}
+
+	if resp := webhook.Handle(context.Background(), scaleRequest(3)); !resp.Allowed {
+		t.Errorf("expected scaling to 3 replicas to be allowed, got %v", resp.Result)
+	}
+	resp := webhook.Handle(context.Background(), scaleRequest(1))
+	if resp.Allowed {
+		t.Fatal("expected scaling below the minimum to be rejected")
+	}
+	if !strings.Contains(resp.Result.Message, "must be at least 2") {
+		t.Errorf("expected the replica floor in the denial, got %q", resp.Result.Message)
+	}
+
+	config := NewValidatingWebhookConfiguration("k8s-controller", "k8s-controller-system", 443, []byte("ca"))
+	if len(config.Webhooks) != 2 || config.Webhooks[1].Rules[0].Resources[0] != "deployments/scale" ||
+		*config.Webhooks[1].ClientConfig.Service.Path != deploymentScaleValidatePath {
+		t.Errorf("expected a webhook for deployments/scale on %s, got %+v", deploymentScaleValidatePath, config.Webhooks)
+	}
+}
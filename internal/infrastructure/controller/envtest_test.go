@@ -0,0 +1,109 @@
+//go:build integration
+
+package controller
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	"k8s-controller/internal/domain"
+	"k8s-controller/internal/infrastructure/metrics"
+)
+
+func TestEnvtestDeploymentReconciler(t *testing.T) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS is not set, skipping envtest integration test")
+	}
+
+	env := &envtest.Environment{}
+	cfg, err := env.Start()
+	if err != nil {
+		t.Fatalf("failed to start envtest: %v", err)
+	}
+	defer func() {
+		if err := env.Stop(); err != nil {
+			t.Errorf("failed to stop envtest: %v", err)
+		}
+	}()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:  scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+	})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	reconciler := NewDeploymentReconciler(mgr.GetClient(), scheme, domain.NewResourceService(nil))
+	if err := reconciler.SetupWithManager(mgr); err != nil {
+		t.Fatalf("failed to set up reconciler: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			t.Errorf("manager stopped: %v", err)
+		}
+	}()
+
+	success := metrics.ReconcileTotal.WithLabelValues(deploymentControllerName, reconcileSuccess)
+	successBefore := testutil.ToFloat64(success)
+
+	// A deployment below its replica floor is observed by the reconciler and scaled up
+	labels := map[string]string{"app": "web"}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "default",
+			Labels:      labels,
+			Annotations: map[string]string{domain.MinReplicasAnnotation: "2"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: "nginx:1.27"}}},
+			},
+		},
+	}
+	if err := mgr.GetClient().Create(ctx, deployment); err != nil {
+		t.Fatalf("failed to create deployment: %v", err)
+	}
+
+	key := types.NamespacedName{Namespace: "default", Name: "web"}
+	err = wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, 30*time.Second, true, func(ctx context.Context) (bool, error) {
+		var current appsv1.Deployment
+		if err := mgr.GetAPIReader().Get(ctx, key, &current); err != nil {
+			return false, err
+		}
+		return *current.Spec.Replicas == 2, nil
+	})
+	if err != nil {
+		t.Fatalf("deployment was not scaled to its replica floor: %v", err)
+	}
+
+	if got := testutil.ToFloat64(success) - successBefore; got < 1 {
+		t.Errorf("expected at least one successful reconcile, got %v", got)
+	}
+}
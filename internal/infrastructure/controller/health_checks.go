@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// cacheSyncer is the part of the manager cache used by CacheSyncedCheck
+type cacheSyncer interface {
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// CacheSyncedCheck returns a check that fails until every informer of the cache has synced.
+// Each probe waits at most timeout for the sync.
+func CacheSyncedCheck(cache cacheSyncer, timeout time.Duration) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		if !cache.WaitForCacheSync(ctx) {
+			return fmt.Errorf("informer caches are not synced")
+		}
+		return nil
+	}
+}
+
+// APIReachableCheck returns a check that fails when the API server does not answer a version
+// request within timeout. The request is bound to the probe's context, so a hung API server
+// doesn't leave requests behind.
+func APIReachableCheck(client rest.Interface, timeout time.Duration) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		if err := client.Get().AbsPath("/version").Do(ctx).Error(); err != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("API server did not answer within %s", timeout)
+			}
+			return fmt.Errorf("API server is not reachable: %w", err)
+		}
+		return nil
+	}
+}
+
+// ReconcileProgress tracks running reconciles and when the last one completed, so a liveness
+// check can tell hung reconcile workers from an idle controller
+type ReconcileProgress struct {
+	inFlight atomic.Int64
+	// lastProgress is the unix nano time a reconcile last completed or, after an idle period,
+	// the first one started, so idle time never counts as a stall
+	lastProgress atomic.Int64
+}
+
+// NewReconcileProgress creates a tracker with no reconciles running
+func NewReconcileProgress() *ReconcileProgress {
+	p := &ReconcileProgress{}
+	p.lastProgress.Store(time.Now().UnixNano())
+	return p
+}
+
+// Started records that a reconcile started
+func (p *ReconcileProgress) Started() {
+	if p.inFlight.Add(1) == 1 {
+		p.lastProgress.Store(time.Now().UnixNano())
+	}
+}
+
+// Completed records that a reconcile returned, successfully or not
+func (p *ReconcileProgress) Completed() {
+	p.lastProgress.Store(time.Now().UnixNano())
+	p.inFlight.Add(-1)
+}
+
+// Stalled reports how many reconciles are running and for how long none has completed, if that
+// is longer than window
+func (p *ReconcileProgress) Stalled(window time.Duration) (inFlight int64, since time.Duration, stalled bool) {
+	inFlight = p.inFlight.Load()
+	since = time.Since(time.Unix(0, p.lastProgress.Load()))
+	return inFlight, since, inFlight > 0 && since > window
+}
+
+// ReconcileProgressCheck returns a liveness check that fails when reconciles are running but
+// none has completed within window, e.g. because every worker is stuck on a blocking call.
+// Kubernetes then restarts the pod.
+func ReconcileProgressCheck(progress *ReconcileProgress, window time.Duration) healthz.Checker {
+	return func(req *http.Request) error {
+		if inFlight, since, stalled := progress.Stalled(window); stalled {
+			return fmt.Errorf("no reconcile completed for %s while %d are running", since.Round(time.Second), inFlight)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+)
+
+func TestCacheSyncedCheck(t *testing.T) {
+	req := httptest.NewRequest("GET", "/readyz", nil)
+
+	synced := &informertest.FakeInformers{Synced: ptr.To(true)}
+	if err := CacheSyncedCheck(synced, time.Second)(req); err != nil {
+		t.Errorf("expected a synced cache to pass, got %v", err)
+	}
+
+	unsynced := &informertest.FakeInformers{Synced: ptr.To(false)}
+	if err := CacheSyncedCheck(unsynced, time.Second)(req); err == nil {
+		t.Error("expected an unsynced cache to fail")
+	}
+}
+
+func TestAPIReachableCheck(t *testing.T) {
+	req := httptest.NewRequest("GET", "/readyz", nil)
+
+	reachable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"major":"1","minor":"33"}`))
+	}))
+	t.Cleanup(reachable.Close)
+	if err := APIReachableCheck(versionClient(reachable.URL), time.Second)(req); err != nil {
+		t.Errorf("expected a reachable API server to pass, got %v", err)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(failing.Close)
+	if err := APIReachableCheck(versionClient(failing.URL), time.Second)(req); err == nil {
+		t.Error("expected an unreachable API server to fail")
+	}
+}
+
+func TestAPIReachableCheckCancelsHungRequest(t *testing.T) {
+	req := httptest.NewRequest("GET", "/readyz", nil)
+
+	cancelled := make(chan struct{})
+	hung := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(cancelled)
+	}))
+	t.Cleanup(hung.Close)
+
+	if err := APIReachableCheck(versionClient(hung.URL), 50*time.Millisecond)(req); err == nil {
+		t.Fatal("expected a hung API server to fail")
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("expected the version request to be cancelled after the timeout")
+	}
+}
+
+// versionClient returns a REST client for the API server at host
+func versionClient(host string) rest.Interface {
+	return discovery.NewDiscoveryClientForConfigOrDie(&rest.Config{Host: host}).RESTClient()
+}
+
+func TestReconcileProgressCheck(t *testing.T) {
+	req := httptest.NewRequest("GET", "/healthz", nil)
+	progress := NewReconcileProgress()
+	check := ReconcileProgressCheck(progress, 50*time.Millisecond)
+
+	// An idle controller is healthy however long no reconcile ran
+	time.Sleep(100 * time.Millisecond)
+	if err := check(req); err != nil {
+		t.Errorf("expected an idle controller to pass, got %v", err)
+	}
+
+	// A reconcile that runs longer than the window fails the check
+	progress.Started()
+	if err := check(req); err != nil {
+		t.Errorf("expected a reconcile that just started to pass, got %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if err := check(req); err == nil {
+		t.Error("expected a stuck reconcile to fail")
+	}
+
+	// Completing it recovers
+	progress.Completed()
+	if err := check(req); err != nil {
+		t.Errorf("expected the check to pass after the reconcile completed, got %v", err)
+	}
+}
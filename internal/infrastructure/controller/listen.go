@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"syscall"
+)
+
+// ErrAddressInUse is returned by NewControllerRuntime when an address the manager listens on
+// is taken by another process and port fallback is disabled or no free port could be selected
+var ErrAddressInUse = errors.New("address already in use")
+
+// resolveBindAddress returns addr if it can be bound. If another process holds it and fallback
+// is set, a free port on the same host is selected and logged, so a second instance or a stray
+// process doesn't make the manager fail at start. Other bind errors are returned as they are.
+func resolveBindAddress(name, addr string, fallback bool) (string, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err == nil {
+		listener.Close()
+		return addr, nil
+	}
+	if !errors.Is(err, syscall.EADDRINUSE) {
+		return "", fmt.Errorf("cannot listen on %s address %s: %w", name, addr, err)
+	}
+	if !fallback {
+		return "", fmt.Errorf("%w: %s address %s is taken by another process (set controller.port-fallback to serve on a free port instead)",
+			ErrAddressInUse, name, addr)
+	}
+
+	host, _, splitErr := net.SplitHostPort(addr)
+	if splitErr != nil {
+		return "", fmt.Errorf("%w: %s address %s: %w", ErrAddressInUse, name, addr, splitErr)
+	}
+
+	// Port 0 makes the kernel pick a free port, which is released again for the manager to bind
+	listener, err = net.Listen("tcp", net.JoinHostPort(host, "0"))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s address %s is taken by another process and no free port is available: %w",
+			ErrAddressInUse, name, addr, err)
+	}
+	chosen := listener.Addr().(*net.TCPAddr)
+	listener.Close()
+
+	selected := net.JoinHostPort(host, fmt.Sprint(chosen.Port))
+	slog.Warn("Address in use, serving on a free port instead", "endpoint", name, "address", addr, "selected", selected)
+	return selected, nil
+}
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestResolveBindAddressKeepsFreeAddress(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	got, err := resolveBindAddress("metrics", addr, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != addr {
+		t.Errorf("expected free address %s to be kept, got %s", addr, got)
+	}
+}
+
+func TestResolveBindAddressSelectsFreePortWhenInUse(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	addr := listener.Addr().String()
+
+	got, err := resolveBindAddress("metrics", addr, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	host, port, err := net.SplitHostPort(got)
+	if err != nil {
+		t.Fatalf("invalid selected address %q: %v", got, err)
+	}
+	if got == addr || host != "127.0.0.1" || port == "0" {
+		t.Errorf("expected another port on 127.0.0.1 than %s, got %s", addr, got)
+	}
+}
+
+func TestResolveBindAddressFailsWhenInUseWithoutFallback(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	addr := listener.Addr().String()
+
+	_, err = resolveBindAddress("metrics", addr, false)
+	if !errors.Is(err, ErrAddressInUse) {
+		t.Fatalf("expected ErrAddressInUse, got %v", err)
+	}
+	if !strings.Contains(err.Error(), addr) {
+		t.Errorf("expected the error to name %s, got %q", addr, err)
+	}
+}
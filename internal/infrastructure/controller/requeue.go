@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Default requeue delays for API errors, see DeploymentReconciler.SetRequeueDelays
+const (
+	DefaultTransientRequeueDelay = 5 * time.Second
+	DefaultPermanentRequeueDelay = 0
+)
+
+// apiErrorClass groups API errors by how a reconcile that hit them is retried
+type apiErrorClass int
+
+const (
+	// apiErrorOther is retried with the controller-runtime backoff
+	apiErrorOther apiErrorClass = iota
+	// apiErrorTransient is expected to clear soon: timeouts, throttling and an unavailable API server
+	apiErrorTransient
+	// apiErrorPermanent does not clear by retrying: missing RBAC, bad credentials and rejected requests
+	apiErrorPermanent
+)
+
+// classifyAPIError reports how a reconcile that failed with err should be retried
+func classifyAPIError(err error) apiErrorClass {
+	switch {
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err), apierrors.IsTooManyRequests(err),
+		apierrors.IsServiceUnavailable(err), errors.Is(err, context.DeadlineExceeded):
+		return apiErrorTransient
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err), apierrors.IsInvalid(err),
+		apierrors.IsBadRequest(err), apierrors.IsMethodNotSupported(err):
+		return apiErrorPermanent
+	default:
+		return apiErrorOther
+	}
+}
+
+// transientDelay returns how long to wait before retrying a transient error: the configured delay,
+// or the delay the API server asked for with Retry-After when that is longer
+func transientDelay(err error, delay time.Duration) time.Duration {
+	if seconds, ok := apierrors.SuggestsClientDelay(err); ok {
+		if suggested := time.Duration(seconds) * time.Second; suggested > delay {
+			return suggested
+		}
+	}
+	return delay
+}
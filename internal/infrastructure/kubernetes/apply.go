@@ -0,0 +1,146 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"k8s-controller/internal/domain"
+)
+
+// defaultApplyAttempts is how often an update hitting a resourceVersion conflict is attempted
+const defaultApplyAttempts = 5
+
+// SetApplyAttempts sets how many times ApplyResource attempts an update that fails with a
+// resourceVersion conflict before returning the conflict error
+func (c *kubeClient) SetApplyAttempts(attempts int) {
+	if attempts > 0 {
+		c.applyAttempts = attempts
+	}
+}
+
+// ApplyResource creates or updates a resource. Only deployments can be applied: Data["image"]
+// sets the image of the first container and the optional Data["replicas"] the replica count.
+// The update re-reads the deployment and is retried when another writer changed it in between.
+func (c *kubeClient) ApplyResource(ctx context.Context, resource domain.Resource) error {
+	loggerFor(ctx).Debug("Applying resource", "kind", resource.Kind, "name", resource.Name, "namespace", resource.Namespace)
+
+	rt, err := mustLookupResourceType(resource.Kind)
+	if err != nil {
+		return err
+	}
+
+	// Reject incomplete resources here with field-level errors instead of an opaque API server error
+	if err := domain.ValidateResource(resource); err != nil {
+		return err
+	}
+
+	if rt.Kind != "Deployment" {
+		return fmt.Errorf("applying %s resources is not supported", rt.Kind)
+	}
+	if c.clientset == nil {
+		return fmt.Errorf("kubernetes client not connected")
+	}
+
+	namespace := resource.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	deployments := c.clientset.AppsV1().Deployments(namespace)
+
+	// Cached lists may miss the change, even a failed attempt may have written it
+	defer c.invalidateListCache(rt.Resource, namespace)
+
+	backoff := retry.DefaultRetry
+	backoff.Steps = c.applyAttempts
+
+	attempt := 0
+	err = retry.RetryOnConflict(backoff, func() error {
+		attempt++
+
+		deployment, err := deployments.Get(ctx, resource.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			_, err = deployments.Create(ctx, newDeploymentFromResource(resource, namespace), metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		applyResourceToDeployment(deployment, resource)
+		_, err = deployments.Update(ctx, deployment, metav1.UpdateOptions{})
+		if apierrors.IsConflict(err) {
+			loggerFor(ctx).Warn("Deployment changed while applying, retrying", "name", resource.Name, "namespace", namespace, "attempt", attempt, "max_attempts", c.applyAttempts)
+		}
+		return err
+	})
+	if err != nil {
+		loggerFor(ctx).Error("Failed to apply resource", "kind", rt.Kind, "name", resource.Name, "namespace", namespace, "attempts", attempt, "error", err)
+		return err
+	}
+
+	loggerFor(ctx).Info("Applied resource", "kind", rt.Kind, "name", resource.Name, "namespace", namespace, "attempts", attempt)
+	return nil
+}
+
+// newDeploymentFromResource builds a single-container deployment selecting its pods by app=<name>
+func newDeploymentFromResource(resource domain.Resource, namespace string) *appsv1.Deployment {
+	selector := map[string]string{"app": resource.Name}
+	podLabels := make(map[string]string, len(resource.Labels)+1)
+	for key, value := range resource.Labels {
+		podLabels[key] = value
+	}
+	podLabels["app"] = resource.Name
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: resource.Name, Namespace: namespace, Labels: resource.Labels},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: resource.Name}},
+				},
+			},
+		},
+	}
+	applyResourceToDeployment(deployment, resource)
+	return deployment
+}
+
+// applyResourceToDeployment sets the labels, image and replicas of the resource on the deployment
+func applyResourceToDeployment(deployment *appsv1.Deployment, resource domain.Resource) {
+	if len(resource.Labels) > 0 && deployment.Labels == nil {
+		deployment.Labels = make(map[string]string, len(resource.Labels))
+	}
+	for key, value := range resource.Labels {
+		deployment.Labels[key] = value
+	}
+
+	if image, ok := resource.Data["image"].(string); ok && len(deployment.Spec.Template.Spec.Containers) > 0 {
+		deployment.Spec.Template.Spec.Containers[0].Image = image
+	}
+	if replicas, ok := int32FromData(resource.Data["replicas"]); ok {
+		deployment.Spec.Replicas = &replicas
+	}
+}
+
+// int32FromData converts a numeric Data value, which may come from JSON as a float64
+func int32FromData(value interface{}) (int32, bool) {
+	switch v := value.(type) {
+	case int:
+		return int32(v), true
+	case int32:
+		return v, true
+	case int64:
+		return int32(v), true
+	case float64:
+		return int32(v), true
+	}
+	return 0, false
+}
@@ -0,0 +1,93 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+
+	"k8s-controller/internal/domain"
+)
+
+// conflictOnUpdate makes the first n deployment updates fail with a conflict
+func conflictOnUpdate(clientset *fake.Clientset, n int) *int {
+	updates := 0
+	clientset.PrependReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		updates++
+		if updates <= n {
+			return true, nil, apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "web", nil)
+		}
+		return false, nil, nil
+	})
+	return &updates
+}
+
+func TestApplyResourceRetriesConflicts(t *testing.T) {
+	existing := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: "web:1"}}}},
+		},
+	}
+	resource := domain.Resource{Kind: "Deployment", Name: "web", Namespace: "default", Data: map[string]interface{}{"image": "web:2", "replicas": float64(3)}}
+	ctx := context.Background()
+
+	client := newTestClient(existing)
+	updates := conflictOnUpdate(client.clientset.(*fake.Clientset), 2)
+
+	if err := client.ApplyResource(ctx, resource); err != nil {
+		t.Fatalf("ApplyResource failed: %v", err)
+	}
+	if *updates != 3 {
+		t.Errorf("expected 3 update attempts, got %d", *updates)
+	}
+	deployment, err := client.clientset.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	if deployment.Spec.Template.Spec.Containers[0].Image != "web:2" || *deployment.Spec.Replicas != 3 {
+		t.Errorf("expected image web:2 and 3 replicas, got %s and %d", deployment.Spec.Template.Spec.Containers[0].Image, *deployment.Spec.Replicas)
+	}
+
+	// The conflict is returned once the attempts are used up
+	client = newTestClient(existing)
+	client.SetApplyAttempts(2)
+	updates = conflictOnUpdate(client.clientset.(*fake.Clientset), 5)
+	if err := client.ApplyResource(ctx, resource); !apierrors.IsConflict(err) {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+	if *updates != 2 {
+		t.Errorf("expected 2 update attempts, got %d", *updates)
+	}
+}
+
+func TestApplyResourceCreatesMissingDeployment(t *testing.T) {
+	client := newTestClient()
+	ctx := context.Background()
+
+	resource := domain.Resource{Kind: "Deployment", Name: "web", Namespace: "default", Labels: map[string]string{"team": "web"}, Data: map[string]interface{}{"image": "web:1"}}
+	if err := client.ApplyResource(ctx, resource); err != nil {
+		t.Fatalf("ApplyResource failed: %v", err)
+	}
+
+	deployment, err := client.clientset.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the deployment to be created: %v", err)
+	}
+	if deployment.Spec.Template.Spec.Containers[0].Image != "web:1" || deployment.Spec.Template.Labels["app"] != "web" || deployment.Labels["team"] != "web" {
+		t.Errorf("unexpected deployment %+v", deployment)
+	}
+
+	if err := client.ApplyResource(ctx, domain.Resource{Kind: "Service", Name: "web"}); err == nil {
+		t.Error("expected applying a service to be rejected")
+	}
+}
@@ -0,0 +1,73 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s-controller/internal/domain"
+)
+
+// AuditLogKey is the config map data key that holds the audit log
+const AuditLogKey = "audit.log"
+
+// configMapDataUpdater updates config map data in place
+type configMapDataUpdater interface {
+	UpdateConfigMapData(ctx context.Context, namespace, name string, update func(data map[string]string)) error
+}
+
+// AuditHandler appends a compact record of each event to a config map, keeping only
+// the most recent entries so the log never outgrows the config map size limit
+type AuditHandler struct {
+	client     configMapDataUpdater
+	namespace  string
+	name       string
+	maxEntries int
+	now        func() time.Time
+}
+
+// NewAuditHandler creates a handler that records events in the named config map,
+// keeping at most maxEntries records
+func NewAuditHandler(client configMapDataUpdater, namespace, name string, maxEntries int) *AuditHandler {
+	return &AuditHandler{
+		client:     client,
+		namespace:  namespace,
+		name:       name,
+		maxEntries: maxEntries,
+		now:        time.Now,
+	}
+}
+
+// HandleEvent appends the event to the audit log, dropping the oldest records over the cap
+func (h *AuditHandler) HandleEvent(ctx context.Context, event domain.ResourceEvent) error {
+	// Writing the audit config map triggers an event for it; recording that would loop forever
+	if event.Resource.Kind == "ConfigMap" && event.Resource.Namespace == h.namespace && event.Resource.Name == h.name {
+		return nil
+	}
+
+	record := fmt.Sprintf("%s %s %s %s/%s",
+		h.now().UTC().Format(time.RFC3339),
+		event.Type,
+		event.Resource.Kind,
+		event.Resource.Namespace,
+		event.Resource.Name)
+
+	err := h.client.UpdateConfigMapData(ctx, h.namespace, h.name, func(data map[string]string) {
+		var entries []string
+		if existing := data[AuditLogKey]; existing != "" {
+			entries = strings.Split(strings.TrimSuffix(existing, "\n"), "\n")
+		}
+		entries = append(entries, record)
+
+		if h.maxEntries > 0 && len(entries) > h.maxEntries {
+			entries = entries[len(entries)-h.maxEntries:]
+		}
+		data[AuditLogKey] = strings.Join(entries, "\n") + "\n"
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write audit record to config map %s/%s: %w", h.namespace, h.name, err)
+	}
+
+	return nil
+}
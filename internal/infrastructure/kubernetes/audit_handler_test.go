@@ -0,0 +1,66 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s-controller/internal/domain"
+)
+
+func TestAuditHandlerKeepsMostRecentEntries(t *testing.T) {
+	client := newTestClient()
+	handler := NewAuditHandler(client, "default", "audit", 2)
+	handler.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	ctx := context.Background()
+	for _, name := range []string{"a", "b", "c"} {
+		event := domain.ResourceEvent{
+			Type:     domain.ResourceEventCreated,
+			Resource: domain.Resource{Kind: "Deployment", Name: name, Namespace: "default"},
+		}
+		if err := handler.HandleEvent(ctx, event); err != nil {
+			t.Fatalf("HandleEvent failed: %v", err)
+		}
+	}
+
+	configMap, err := client.clientset.CoreV1().ConfigMaps("default").Get(ctx, "audit", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get audit config map: %v", err)
+	}
+
+	entries := strings.Split(strings.TrimSuffix(configMap.Data[AuditLogKey], "\n"), "\n")
+	want := []string{
+		"2025-01-02T03:04:05Z CREATED Deployment default/b",
+		"2025-01-02T03:04:05Z CREATED Deployment default/c",
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries, got %d: %q", len(want), len(entries), entries)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("entry %d: expected %q, got %q", i, want[i], entries[i])
+		}
+	}
+}
+
+func TestAuditHandlerSkipsItsOwnConfigMap(t *testing.T) {
+	client := newTestClient()
+	handler := NewAuditHandler(client, "default", "audit", 10)
+
+	ctx := context.Background()
+	event := domain.ResourceEvent{
+		Type:     domain.ResourceEventUpdated,
+		Resource: domain.Resource{Kind: "ConfigMap", Name: "audit", Namespace: "default"},
+	}
+	if err := handler.HandleEvent(ctx, event); err != nil {
+		t.Fatalf("HandleEvent failed: %v", err)
+	}
+
+	if _, err := client.clientset.CoreV1().ConfigMaps("default").Get(ctx, "audit", metav1.GetOptions{}); err == nil {
+		t.Error("expected no audit record for an event about the audit config map")
+	}
+}
@@ -4,16 +4,23 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/homedir"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 
 	"k8s-controller/internal/domain"
 )
@@ -24,65 +31,375 @@ import (
 type Client interface {
 	domain.ResourceClient
 	SetEventHandler(handler ResourceEventHandler)
+	AddEventHandler(handler ResourceEventHandler)
+	ReplayEvent(ctx context.Context, event domain.ResourceEvent) error
 	ListDeployments(ctx context.Context, namespace string) ([]domain.Deployment, error)
+	ListDeploymentsBySelector(ctx context.Context, namespace, selector string) ([]domain.Deployment, error)
+	ListDeploymentsByLabel(ctx context.Context, namespace, key, value string) ([]domain.Deployment, error)
+	ListServices(ctx context.Context, namespace, selector string) ([]domain.Service, error)
+	ListPods(ctx context.Context, namespace, selector string) ([]domain.Pod, error)
+	ListIngresses(ctx context.Context, namespace, selector string) ([]domain.Ingress, error)
+	GetIngress(ctx context.Context, namespace, name string) (domain.Ingress, error)
+	ListJobs(ctx context.Context, namespace, selector string) ([]domain.Job, error)
+	GetJob(ctx context.Context, namespace, name string) (domain.Job, error)
+	ListCronJobs(ctx context.Context, namespace, selector string) ([]domain.CronJob, error)
+	GetCronJob(ctx context.Context, namespace, name string) (domain.CronJob, error)
+	SummarizeResources(ctx context.Context, namespace string) (domain.ResourceSummary, error)
+	ExportManifests(ctx context.Context, namespace string, resources []string) ([]byte, error)
+	ListPodMetrics(ctx context.Context, namespace string) ([]domain.PodMetrics, error)
+	GetDeployment(ctx context.Context, namespace, name string) (domain.Deployment, error)
+	ListDeploymentHistory(ctx context.Context, namespace, name string) ([]domain.DeploymentRevision, error)
+	GetDeploymentTree(ctx context.Context, namespace, name string) (domain.DeploymentTree, error)
+	RollbackDeployment(ctx context.Context, namespace, name string, revision int64) (domain.DeploymentRevision, error)
+	ListNamespaces(ctx context.Context) ([]string, error)
+	ListConfigMaps(ctx context.Context, namespace string) ([]domain.ConfigMap, error)
+	GetConfigMap(ctx context.Context, namespace, name string) (domain.ConfigMap, error)
+	UpdateConfigMapData(ctx context.Context, namespace, name string, update func(data map[string]string)) error
 	GetDeploymentInformer(namespace string) (cache.SharedIndexInformer, error)
 	InitializeInformers(ctx context.Context, namespaces []string) error
 	SetNamespaces(namespaces []string)
+	SetDiscoverNamespaces(discover bool)
+	ResolveNamespaces(ctx context.Context) []string
 	SetWatchedResources(resources []string)
+	SetResyncPeriods(defaultPeriod time.Duration, periods map[string]time.Duration)
+	SetMaxEventRetries(retries int)
+	SetEventBuffer(size int, dropWhenFull bool)
+	SetApplyAttempts(attempts int)
+	SetIndexLabels(keys []string)
+	SetWatchSelector(selector string)
+	SetResourceSelectors(selectors map[string]string)
+	SetListCacheTTL(ttl time.Duration)
+	SetInformerScope(clusterScopeThreshold int, perNamespace bool)
+	SetImpersonation(user string, groups []string)
+	SetConnection(opts ConnectionOptions)
+	CheckPermissions(ctx context.Context, namespaces, resources []string) error
+	Stop()
+	// EventsDrained is closed once the event worker started by WatchResources has exited
+	EventsDrained() <-chan struct{}
+	WatchStatus() WatchStatus
+	NewSnapshotInformer(ctx context.Context, name, namespace string, resyncPeriod time.Duration) (*SnapshotInformer, error)
+}
+
+// WatchStatus describes what the client is watching and whether its caches have synced
+type WatchStatus struct {
+	Namespaces    []string
+	Resources     []string
+	ResyncPeriod  time.Duration
+	ResyncPeriods map[string]time.Duration
+	CacheSynced   map[string]bool
+	ClusterScoped bool
+	// DegradedInformers maps namespace/resource of forbidden informers to their error
+	DegradedInformers map[string]string
 }
 
 // kubeClient is a concrete implementation of the Client interface
 type kubeClient struct {
-	clientset         *kubernetes.Clientset
-	eventHandler      ResourceEventHandler
+	clientset         kubernetes.Interface
+	metricsClientset  metricsclientset.Interface
+	eventHandlers     []ResourceEventHandler
+	handlersMu        sync.RWMutex
 	informerFactories map[string]informers.SharedInformerFactory
+	factoriesMu       sync.RWMutex
+	handledInformers  map[string]bool
+	createdInformers  map[string]bool
+	// informerStops holds the stop channel of each started informer, keyed like createdInformers
+	informerStops map[string]*informerStop
+	// informersRunning tracks the goroutines of the informers started by startInformer
+	informersRunning sync.WaitGroup
+	// degradedInformers records informers stopped by forbidden errors and the error message
+	degradedInformers map[string]string
+	stopCh            chan struct{}
+	stopOnce          sync.Once
+	snapshotInformers map[string]*SnapshotInformer
+	snapshotMu        sync.Mutex
 	namespaces        []string
+	discoverNS        bool
 	watchedResources  []string
+	resyncPeriod      time.Duration
+	resyncPeriods     map[string]time.Duration
+	eventQueue        workqueue.TypedRateLimitingInterface[*queuedEvent]
+	maxEventRetries   int
+	// workerDone is closed when the event worker exits; nil until WatchResources starts it
+	workerDone chan struct{}
+	workerMu   sync.Mutex
+	// namespacesMu guards namespaces, namespacesDiscovered and the informer scope, which discovery
+	// changes while event and HTTP handlers read them
+	namespacesMu sync.RWMutex
+	// namespacesDiscovered is set once ResolveNamespaces has run the namespace discovery
+	namespacesDiscovered bool
+	// scopeFixed is set when the first informer factory is created; from then on clusterScope
+	// holds the scope decision, so discovered namespaces can't switch the factory layout
+	scopeFixed   bool
+	clusterScope bool
+	// eventBuffer holds one token per queued event delivery, bounding the queue; nil when unbounded
+	eventBuffer chan struct{}
+	// dropWhenFull drops events instead of blocking the informers when eventBuffer is full
+	dropWhenFull      bool
+	applyAttempts     int
+	impersonateUser   string
+	impersonateGroups []string
+	// clusterScopeThreshold is the number of namespaces above which one cluster-scoped
+	// informer factory replaces the per-namespace factories; 0 disables it
+	clusterScopeThreshold int
+	perNamespaceFactories bool
+	// indexLabels are label keys with an informer index on deployments
+	indexLabels []string
+	// watchSelector restricts informers to objects matching the label selector; empty watches everything
+	watchSelector string
+	// resourceSelectors restrict the informer of single resource types, keyed by plural resource name
+	resourceSelectors map[string]string
+	// listCache holds direct API list results when no synced informer exists; nil disables it
+	listCache *listCache
+	// optionErr is returned by Connect when an option could not be applied
+	optionErr error
+	// injected marks a clientset supplied by WithClientset, which Connect keeps
+	injected bool
+	// restConfig replaces the kubeconfig when set by WithConfig
+	restConfig *rest.Config
+	// connection selects the kubeconfig, context and rate limits used by Connect
+	connection ConnectionOptions
+}
+
+// ClientOption configures a client created by NewClient
+type ClientOption func(*kubeClient)
+
+// WithClientset makes the client use the given clientset instead of connecting with the
+// kubeconfig. It is mainly used to run the client against a fake clientset in tests.
+func WithClientset(clientset kubernetes.Interface) ClientOption {
+	return func(c *kubeClient) {
+		c.clientset = clientset
+		c.injected = true
+	}
+}
+
+// WithMetricsClientset makes the client read pod metrics from the given clientset
+func WithMetricsClientset(clientset metricsclientset.Interface) ClientOption {
+	return func(c *kubeClient) {
+		c.metricsClientset = clientset
+	}
+}
+
+// WithConfig makes Connect build its clientsets from the given rest config instead of the
+// kubeconfig file. The config is copied, so impersonation does not modify the caller's config.
+func WithConfig(config *rest.Config) ClientOption {
+	return func(c *kubeClient) {
+		c.restConfig = rest.CopyConfig(config)
+	}
 }
 
 // NewClient creates a new Kubernetes client with sensible defaults
-func NewClient() Client {
-	return &kubeClient{
-		informerFactories: make(map[string]informers.SharedInformerFactory),
-		namespaces:        []string{"default"},
-		watchedResources:  []string{"deployments", "services", "pods"},
+func NewClient(opts ...ClientOption) Client {
+	c := &kubeClient{
+		informerFactories:     make(map[string]informers.SharedInformerFactory),
+		handledInformers:      make(map[string]bool),
+		createdInformers:      make(map[string]bool),
+		informerStops:         make(map[string]*informerStop),
+		degradedInformers:     make(map[string]string),
+		stopCh:                make(chan struct{}),
+		snapshotInformers:     make(map[string]*SnapshotInformer),
+		namespaces:            []string{"default"},
+		watchedResources:      []string{"deployments", "services", "pods"},
+		resyncPeriod:          30 * time.Second,
+		resyncPeriods:         make(map[string]time.Duration),
+		eventQueue:            newEventQueue(),
+		maxEventRetries:       defaultMaxEventRetries,
+		applyAttempts:         defaultApplyAttempts,
+		clusterScopeThreshold: defaultClusterScopeThreshold,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // SetNamespaces sets the namespaces to watch
 func (c *kubeClient) SetNamespaces(namespaces []string) {
 	if len(namespaces) > 0 {
+		c.namespacesMu.Lock()
 		c.namespaces = namespaces
+		c.namespacesMu.Unlock()
 	}
 }
 
-// SetWatchedResources sets the types of resources to watch
+// watchedNamespaces returns the namespaces to watch. The slice is replaced, never modified,
+// so callers can range over it without holding the lock.
+func (c *kubeClient) watchedNamespaces() []string {
+	c.namespacesMu.RLock()
+	defer c.namespacesMu.RUnlock()
+	return c.namespaces
+}
+
+// SetDiscoverNamespaces enables watching all namespaces in the cluster, discovered at startup
+func (c *kubeClient) SetDiscoverNamespaces(discover bool) {
+	c.discoverNS = discover
+}
+
+// SetWatchedResources sets the types of resources to watch. Short and singular names such as
+// po or deployment are normalized to their plural resource names.
 func (c *kubeClient) SetWatchedResources(resources []string) {
 	if len(resources) > 0 {
-		c.watchedResources = resources
+		c.watchedResources = NormalizeResourceTypes(resources)
 	}
 }
 
-// SetEventHandler sets the handler for resource events
+// SetResyncPeriods sets the default informer resync period and optional per-resource overrides
+func (c *kubeClient) SetResyncPeriods(defaultPeriod time.Duration, periods map[string]time.Duration) {
+	if defaultPeriod > 0 {
+		c.resyncPeriod = defaultPeriod
+	}
+	if periods != nil {
+		c.resyncPeriods = periods
+	}
+}
+
+// SetEventBuffer bounds the number of event deliveries waiting for or being handled to size.
+// When the buffer is full, events are dropped if dropWhenFull is set, otherwise the informer
+// callbacks block until the worker frees space. A size of 0 leaves the queue unbounded.
+// It must be called before events are queued.
+func (c *kubeClient) SetEventBuffer(size int, dropWhenFull bool) {
+	c.eventBuffer = nil
+	if size > 0 {
+		c.eventBuffer = make(chan struct{}, size)
+	}
+	c.dropWhenFull = dropWhenFull
+}
+
+// SetListCacheTTL caches the results of lists served by the API, because no synced informer
+// exists, for ttl. Lists with the same resource, namespace and selector within the TTL reuse the
+// result unless their context comes from WithoutListCache. Writes made through the client drop
+// the affected lists. A ttl of 0 disables the cache.
+func (c *kubeClient) SetListCacheTTL(ttl time.Duration) {
+	c.listCache = nil
+	if ttl > 0 {
+		c.listCache = newListCache(ttl)
+	}
+}
+
+// SetMaxEventRetries sets how many times a failed event is retried before it is dropped
+func (c *kubeClient) SetMaxEventRetries(retries int) {
+	if retries >= 0 {
+		c.maxEventRetries = retries
+	}
+}
+
+// SetInformerScope sets how many namespaces can be watched before the client switches to a single
+// cluster-scoped informer factory. perNamespace forces one factory per namespace regardless.
+func (c *kubeClient) SetInformerScope(clusterScopeThreshold int, perNamespace bool) {
+	c.namespacesMu.Lock()
+	defer c.namespacesMu.Unlock()
+	if clusterScopeThreshold >= 0 {
+		c.clusterScopeThreshold = clusterScopeThreshold
+	}
+	c.perNamespaceFactories = perNamespace
+}
+
+// SetIndexLabels sets the label keys to index on the deployment informer. Each index
+// speeds up equality lookups on that label at the cost of extra memory per deployment.
+func (c *kubeClient) SetIndexLabels(keys []string) {
+	c.indexLabels = keys
+}
+
+// SetWatchSelector restricts the informers to objects matching the label selector. The
+// selector is sent to the API server, so unmatched objects never enter the cache. It must
+// be set before the informers are created.
+func (c *kubeClient) SetWatchSelector(selector string) {
+	c.watchSelector = selector
+}
+
+// SetResourceSelectors restricts the informers of single resource types to objects matching a
+// label selector, e.g. {"deployments": "team=payments"} caches only that team's deployments while
+// other types are watched in full. Keys may be any name NormalizeResourceType accepts. The
+// selectors add to the watch selector and must be set before the informers are created.
+func (c *kubeClient) SetResourceSelectors(selectors map[string]string) {
+	c.resourceSelectors = make(map[string]string, len(selectors))
+	for resource, selector := range selectors {
+		normalized, ok := NormalizeResourceType(resource)
+		if !ok {
+			slog.Warn("Ignoring label selector for unsupported resource type", "resource", resource)
+			continue
+		}
+		if selector = strings.TrimSpace(selector); selector != "" {
+			c.resourceSelectors[normalized] = selector
+		}
+	}
+}
+
+// SetConnection sets the kubeconfig, context and rate limits Connect builds the rest config from.
+// It must be called before Connect to take effect.
+func (c *kubeClient) SetConnection(opts ConnectionOptions) {
+	c.connection = opts
+}
+
+// ValidateWatchSelector checks that the watch selector is a valid label selector
+func ValidateWatchSelector(selector string) error {
+	if _, err := labels.Parse(selector); err != nil {
+		return fmt.Errorf("invalid watch selector %q: %w", selector, err)
+	}
+	return nil
+}
+
+// SetEventHandler replaces all registered handlers with the given handler.
+// Passing nil removes all handlers.
 func (c *kubeClient) SetEventHandler(handler ResourceEventHandler) {
-	c.eventHandler = handler
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+
+	c.eventHandlers = nil
+	if handler != nil {
+		c.eventHandlers = append(c.eventHandlers, handler)
+	}
+}
+
+// AddEventHandler registers an additional handler for resource events.
+// It is safe to call while informers are running.
+func (c *kubeClient) AddEventHandler(handler ResourceEventHandler) {
+	if handler == nil {
+		return
+	}
+
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+
+	c.eventHandlers = append(c.eventHandlers, handler)
+}
+
+// getEventHandlers returns a snapshot of the registered handlers
+func (c *kubeClient) getEventHandlers() []ResourceEventHandler {
+	c.handlersMu.RLock()
+	defer c.handlersMu.RUnlock()
+
+	handlers := make([]ResourceEventHandler, len(c.eventHandlers))
+	copy(handlers, c.eventHandlers)
+	return handlers
 }
 
 // Connect establishes a connection to the Kubernetes cluster
 func (c *kubeClient) Connect(ctx context.Context) error {
 	slog.Info("Connecting to Kubernetes cluster")
 
-	// Use default kubeconfig location
-	home := homedir.HomeDir()
-	kubeconfigPath := filepath.Join(home, ".kube", "config")
+	if c.optionErr != nil {
+		return c.optionErr
+	}
+
+	if c.injected {
+		slog.Info("Using injected clientset")
+		return nil
+	}
 
-	// Build the config from the kubeconfig file
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	config, err := c.loadConfig()
 	if err != nil {
 		slog.Error("Failed to build config from flags", "error", err)
 		return err
 	}
 
+	// Run all API calls as the impersonated identity once the base credentials are verified
+	if c.impersonateUser != "" || len(c.impersonateGroups) > 0 {
+		if err := c.applyImpersonation(ctx, config); err != nil {
+			slog.Error("Failed to configure impersonation", "error", err)
+			return err
+		}
+	}
+
 	// Create the clientset
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
@@ -90,108 +407,328 @@ func (c *kubeClient) Connect(ctx context.Context) error {
 		return err
 	}
 
+	// Create the metrics clientset; requests fail later if metrics-server is not installed
+	metricsClientset, err := metricsclientset.NewForConfig(config)
+	if err != nil {
+		slog.Error("Failed to create metrics client", "error", err)
+		return err
+	}
+
 	c.clientset = clientset
+	c.metricsClientset = metricsClientset
 	slog.Info("Successfully connected to Kubernetes cluster")
 	return nil
 }
 
+// loadConfig returns a copy of the injected rest config, or builds one from the connection options
+func (c *kubeClient) loadConfig() (*rest.Config, error) {
+	if c.restConfig != nil {
+		return rest.CopyConfig(c.restConfig), nil
+	}
+	return BuildRESTConfig(c.connection)
+}
+
 // WatchResources starts watching for resource events
 func (c *kubeClient) WatchResources(ctx context.Context) error {
 	slog.Info("Starting to watch resources")
 
-	if c.eventHandler == nil {
-		slog.Warn("No event handler set, resource events will not be processed")
-		return nil
+	if len(c.getEventHandlers()) == 0 {
+		slog.Warn("No event handler set yet, resource events will be dropped until one is registered")
 	}
 
+	// Replace the configured namespaces with all cluster namespaces if requested
+	namespaces := c.ResolveNamespaces(ctx)
+
 	// First initialize informers to ensure cache is ready
-	if err := c.InitializeInformers(ctx, c.namespaces); err != nil {
+	if err := c.InitializeInformers(ctx, namespaces); err != nil {
 		return err
 	}
 
+	// Stop the shared informers when watching is cancelled
+	go func() {
+		<-ctx.Done()
+		c.Stop()
+	}()
+
+	// Deliver queued events to the handlers, retrying failures with backoff
+	c.startEventWorker(ctx)
+
 	// Then start watching resources with event handlers
-	return c.startInformers(ctx, c.namespaces, c.watchedResources, c.eventHandler)
+	return c.startInformers(ctx, namespaces, c.watchedResources)
 }
 
-// GetResource retrieves a specific resource
-func (c *kubeClient) GetResource(ctx context.Context, kind, name, namespace string) (domain.Resource, error) {
-	slog.Debug("Getting resource", "kind", kind, "name", name, "namespace", namespace)
+// ResolveNamespaces returns the namespaces to watch. With namespace discovery enabled, the
+// namespaces of the cluster are listed the first time and replace the configured ones.
+func (c *kubeClient) ResolveNamespaces(ctx context.Context) []string {
+	c.namespacesMu.RLock()
+	discover := c.discoverNS && !c.namespacesDiscovered
+	c.namespacesMu.RUnlock()
 
-	// Implementation would use the clientset to get the resource
-	// This is a placeholder
-	return domain.Resource{}, nil
+	// List without holding the lock, so readers aren't blocked by the API call
+	if discover {
+		c.discoverNamespaces(ctx)
+	}
+	return c.watchedNamespaces()
 }
 
-// ApplyResource creates or updates a resource
-func (c *kubeClient) ApplyResource(ctx context.Context, resource domain.Resource) error {
-	slog.Debug("Applying resource", "kind", resource.Kind, "name", resource.Name, "namespace", resource.Namespace)
+// discoverNamespaces populates the watch list with all namespaces in the cluster.
+// If namespaces cannot be listed, the configured namespaces are kept.
+func (c *kubeClient) discoverNamespaces(ctx context.Context) {
+	namespaces, err := c.ListNamespaces(ctx)
 
-	// Implementation would use the clientset to apply the resource
-	// This is a placeholder
-	return nil
+	c.namespacesMu.Lock()
+	defer c.namespacesMu.Unlock()
+	if c.namespacesDiscovered {
+		return
+	}
+	c.namespacesDiscovered = true
+
+	if err != nil {
+		slog.Warn("Failed to discover namespaces, using configured namespaces", "namespaces", c.namespaces, "error", err)
+		return
+	}
+
+	if len(namespaces) == 0 {
+		slog.Warn("No namespaces discovered, using configured namespaces", "namespaces", c.namespaces)
+		return
+	}
+
+	slog.Info("Discovered namespaces", "namespaces", namespaces)
+	c.namespaces = namespaces
+}
+
+// GetResource retrieves a specific resource of any supported kind. Data holds the full
+// object with status and server-managed fields removed.
+func (c *kubeClient) GetResource(ctx context.Context, kind, name, namespace string) (domain.Resource, error) {
+	loggerFor(ctx).Debug("Getting resource", "kind", kind, "name", name, "namespace", namespace)
+
+	rt, err := mustLookupResourceType(kind)
+	if err != nil {
+		return domain.Resource{}, err
+	}
+
+	obj, err := c.getObject(ctx, kind, namespace, name)
+	if err != nil {
+		return domain.Resource{}, err
+	}
+
+	// Include the full object without server-managed fields, as it would be applied
+	data, err := exportManifest(rt, obj)
+	if err != nil {
+		return domain.Resource{}, err
+	}
+
+	resource := c.convertToDomainResource(obj)
+	resource.APIVersion = rt.APIVersion()
+	resource.Data = data
+	return resource, nil
 }
 
 // ListDeployments retrieves all deployments in the specified namespace using the informer cache
 func (c *kubeClient) ListDeployments(ctx context.Context, namespace string) ([]domain.Deployment, error) {
-	slog.Debug("Listing deployments from cache", "namespace", namespace)
+	return c.ListDeploymentsBySelector(ctx, namespace, "")
+}
 
+// ListWatchedDeployments retrieves the deployments of every watched namespace using the informer cache
+func (c *kubeClient) ListWatchedDeployments(ctx context.Context) ([]domain.Deployment, error) {
+	var deployments []domain.Deployment
+	for _, namespace := range c.watchedNamespaces() {
+		namespaceDeployments, err := c.ListDeployments(ctx, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deployments in namespace %s: %w", namespace, err)
+		}
+		deployments = append(deployments, namespaceDeployments...)
+	}
+	return deployments, nil
+}
+
+// ListDeploymentsBySelector retrieves deployments in the namespace matching the label selector,
+// using the informer cache when available. An empty selector matches everything.
+func (c *kubeClient) ListDeploymentsBySelector(ctx context.Context, namespace, selector string) ([]domain.Deployment, error) {
+	loggerFor(ctx).Debug("Listing deployments", "namespace", namespace, "selector", selector)
+
+	objects, err := c.listObjects(ctx, "deployments", namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	deployments := make([]domain.Deployment, 0, len(objects))
+	for _, obj := range objects {
+		deployments = append(deployments, ToDomainDeployment(obj.(*appsv1.Deployment)))
+	}
+
+	loggerFor(ctx).Info("Successfully listed deployments", "count", len(deployments), "namespace", namespace)
+	return deployments, nil
+}
+
+// GetDeployment retrieves a single deployment, preferring the informer cache over a direct API call
+func (c *kubeClient) GetDeployment(ctx context.Context, namespace, name string) (domain.Deployment, error) {
+	loggerFor(ctx).Debug("Getting deployment", "name", name, "namespace", namespace)
+
+	obj, err := c.getObject(ctx, "deployments", namespace, name)
+	if err != nil {
+		return domain.Deployment{}, err
+	}
+	return ToDomainDeployment(obj.(*appsv1.Deployment)), nil
+}
+
+// ListNamespaces retrieves the names of all namespaces in the cluster
+func (c *kubeClient) ListNamespaces(ctx context.Context) ([]string, error) {
 	if c.clientset == nil {
 		return nil, fmt.Errorf("kubernetes client not connected")
 	}
 
-	// Check if we have an informer for this namespace
-	factory, ok := c.informerFactories[namespace]
-	if !ok {
-		slog.Warn("No informer factory for namespace, falling back to direct API call", "namespace", namespace)
-		// Fall back to direct API call if no informer is available
-		deploymentList, err := c.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			slog.Error("Failed to list deployments", "error", err, "namespace", namespace)
-			return nil, err
-		}
+	namespaceList, err := c.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		loggerFor(ctx).Error("Failed to list namespaces", "error", err)
+		return nil, err
+	}
 
-		var deployments []domain.Deployment
-		for _, dep := range deploymentList.Items {
-			deployment := domain.Deployment{
-				Name:              dep.Name,
-				Namespace:         dep.Namespace,
-				ReadyReplicas:     dep.Status.ReadyReplicas,
-				UpdatedReplicas:   dep.Status.UpdatedReplicas,
-				AvailableReplicas: dep.Status.AvailableReplicas,
-				Replicas:          *dep.Spec.Replicas,
-				Labels:            dep.Labels,
-				CreationTimestamp: dep.CreationTimestamp.Format("2006-01-02 15:04:05"),
-			}
-			deployments = append(deployments, deployment)
-		}
-		return deployments, nil
+	names := make([]string, 0, len(namespaceList.Items))
+	for _, ns := range namespaceList.Items {
+		names = append(names, ns.Name)
 	}
 
-	// Get the store from the informer
-	lister := factory.Apps().V1().Deployments().Lister()
-	deploymentList, err := lister.Deployments(namespace).List(labels.Everything())
+	return names, nil
+}
+
+// ListConfigMaps retrieves all config maps in the specified namespace
+func (c *kubeClient) ListConfigMaps(ctx context.Context, namespace string) ([]domain.ConfigMap, error) {
+	loggerFor(ctx).Debug("Listing config maps", "namespace", namespace)
+
+	objects, err := c.listObjects(ctx, "configmaps", namespace, "")
 	if err != nil {
-		slog.Error("Failed to list deployments from cache", "error", err, "namespace", namespace)
 		return nil, err
 	}
 
-	var deployments []domain.Deployment
-	for _, dep := range deploymentList {
-		deployment := domain.Deployment{
-			Name:              dep.Name,
-			Namespace:         dep.Namespace,
-			ReadyReplicas:     dep.Status.ReadyReplicas,
-			UpdatedReplicas:   dep.Status.UpdatedReplicas,
-			AvailableReplicas: dep.Status.AvailableReplicas,
-			Replicas:          *dep.Spec.Replicas,
-			Labels:            dep.Labels,
-			CreationTimestamp: dep.CreationTimestamp.Format("2006-01-02 15:04:05"),
+	configMaps := make([]domain.ConfigMap, 0, len(objects))
+	for _, obj := range objects {
+		configMaps = append(configMaps, toDomainConfigMap(obj.(*corev1.ConfigMap)))
+	}
+
+	loggerFor(ctx).Info("Successfully listed config maps", "count", len(configMaps), "namespace", namespace)
+	return configMaps, nil
+}
+
+// GetConfigMap retrieves a single config map
+func (c *kubeClient) GetConfigMap(ctx context.Context, namespace, name string) (domain.ConfigMap, error) {
+	loggerFor(ctx).Debug("Getting config map", "name", name, "namespace", namespace)
+
+	obj, err := c.getObject(ctx, "configmaps", namespace, name)
+	if err != nil {
+		return domain.ConfigMap{}, err
+	}
+	return toDomainConfigMap(obj.(*corev1.ConfigMap)), nil
+}
+
+// UpdateConfigMapData applies update to the config map data and saves it, creating the
+// config map if it does not exist. The update is retried on conflicts, so it must be
+// safe to call more than once.
+func (c *kubeClient) UpdateConfigMapData(ctx context.Context, namespace, name string, update func(data map[string]string)) error {
+	if c.clientset == nil {
+		return fmt.Errorf("kubernetes client not connected")
+	}
+
+	defer c.invalidateListCache("configmaps", namespace)
+
+	configMaps := c.clientset.CoreV1().ConfigMaps(namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := configMaps.Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Data:       make(map[string]string),
+			}
+			update(configMap.Data)
+			_, err = configMaps.Create(ctx, configMap, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				// Created concurrently, retry as an update
+				return apierrors.NewConflict(corev1.Resource("configmaps"), name, err)
+			}
+			return err
 		}
-		deployments = append(deployments, deployment)
+		if err != nil {
+			return err
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = make(map[string]string)
+		}
+		update(configMap.Data)
+		_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// toDomainConfigMap converts a Kubernetes config map to the domain model
+func toDomainConfigMap(cm *corev1.ConfigMap) domain.ConfigMap {
+	keys := make([]string, 0, len(cm.Data)+len(cm.BinaryData))
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+	for key := range cm.BinaryData {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+
+	return domain.ConfigMap{
+		Name:      cm.Name,
+		Namespace: cm.Namespace,
+		DataKeys:  keys,
+		Data:      cm.Data,
+		Labels:    cm.Labels,
+	}
+}
 
-	slog.Info("Successfully listed deployments", "count", len(deployments), "namespace", namespace)
-	return deployments, nil
+// ToDomainDeployment converts a Kubernetes deployment to the domain model
+func ToDomainDeployment(dep *appsv1.Deployment) domain.Deployment {
+	var replicas int32
+	if dep.Spec.Replicas != nil {
+		replicas = *dep.Spec.Replicas
+	}
+
+	images := make([]string, 0, len(dep.Spec.Template.Spec.Containers))
+	for _, container := range dep.Spec.Template.Spec.Containers {
+		images = append(images, container.Image)
+	}
+
+	return domain.Deployment{
+		Name:              dep.Name,
+		Namespace:         dep.Namespace,
+		ReadyReplicas:     dep.Status.ReadyReplicas,
+		UpdatedReplicas:   dep.Status.UpdatedReplicas,
+		AvailableReplicas: dep.Status.AvailableReplicas,
+		Replicas:          replicas,
+		Labels:            dep.Labels,
+		Annotations:       dep.Annotations,
+		Images:            images,
+		CreationTimestamp: dep.CreationTimestamp.UTC().Format(time.RFC3339),
+		Status: domain.DeploymentStatus{
+			ReadyReplicas:       dep.Status.ReadyReplicas,
+			UpdatedReplicas:     dep.Status.UpdatedReplicas,
+			AvailableReplicas:   dep.Status.AvailableReplicas,
+			UnavailableReplicas: dep.Status.UnavailableReplicas,
+			Conditions:          ToDomainConditions(dep.Status.Conditions),
+		},
+		CreatedAt:          dep.CreationTimestamp.UTC(),
+		Generation:         dep.Generation,
+		ObservedGeneration: dep.Status.ObservedGeneration,
+	}
+}
+
+// ToDomainConditions converts Kubernetes deployment conditions to the domain model
+func ToDomainConditions(conditions []appsv1.DeploymentCondition) []domain.DeploymentCondition {
+	result := make([]domain.DeploymentCondition, 0, len(conditions))
+	for _, condition := range conditions {
+		result = append(result, domain.DeploymentCondition{
+			Type:               string(condition.Type),
+			Status:             string(condition.Status),
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: condition.LastTransitionTime.UTC(),
+		})
+	}
+	return result
 }
 
 // InitializeInformers initializes informer factories for specified namespaces
@@ -200,6 +737,12 @@ func (c *kubeClient) InitializeInformers(ctx context.Context, namespaces []strin
 		return fmt.Errorf("kubernetes client not connected")
 	}
 
+	// Bail out before starting anything if the context is already done,
+	// since factories started with a closed stop channel never sync
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("informer initialization cancelled: %w", err)
+	}
+
 	slog.Info("Initializing informer factories", "namespaces", namespaces)
 
 	// If no namespaces provided, use default
@@ -207,24 +750,19 @@ func (c *kubeClient) InitializeInformers(ctx context.Context, namespaces []strin
 		namespaces = []string{"default"}
 	}
 
-	// Create a factory for each namespace with 30s resync period
-	for _, namespace := range namespaces {
-		factory := informers.NewSharedInformerFactoryWithOptions(
-			c.clientset,
-			30*time.Second, // resync period
-			informers.WithNamespace(namespace),
-		)
+	deployments, _ := lookupResourceType("deployments")
 
-		// Pre-create some commonly used informers to ensure they are available
-		// This doesn't start watching yet, just creates the informers
-		factory.Apps().V1().Deployments().Informer()
+	// Create a factory for each namespace with the configured resync periods
+	for _, namespace := range namespaces {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("informer initialization cancelled: %w", err)
+		}
 
-		// Store the factory
-		c.informerFactories[namespace] = factory
+		factory := c.getOrCreateInformerFactory(namespace)
 
-		// Start the informer factory with a background context
-		// This ensures we don't block even if the parent context is cancelled
-		factory.Start(ctx.Done())
+		// Pre-create the deployment informer; it runs for the lifetime of the client rather
+		// than the caller's context, so short-lived request contexts don't stop it
+		c.createInformer(factory, namespace, deployments)
 
 		slog.Info("Started informer factory", "namespace", namespace)
 	}
@@ -233,9 +771,12 @@ func (c *kubeClient) InitializeInformers(ctx context.Context, namespaces []strin
 	syncCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	for namespace, factory := range c.informerFactories {
+	for namespace, factory := range c.snapshotInformerFactories() {
 		deployInformer := factory.Apps().V1().Deployments().Informer()
 		if !cache.WaitForCacheSync(syncCtx.Done(), deployInformer.HasSynced) {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("informer initialization cancelled: %w", err)
+			}
 			slog.Warn("Timeout waiting for deployment cache to sync", "namespace", namespace)
 			// Continue despite timeout - the cache will eventually sync
 		} else {
@@ -246,9 +787,63 @@ func (c *kubeClient) InitializeInformers(ctx context.Context, namespaces []strin
 	return nil
 }
 
+// Stop stops all informers started by the client and waits for their goroutines to exit.
+// It is safe to call more than once.
+func (c *kubeClient) Stop() {
+	c.stopOnce.Do(func() {
+		slog.Info("Stopping informers")
+		close(c.stopCh)
+
+		// Wait until every informer goroutine has returned. Taking the lock first lets a
+		// concurrent startInformer finish registering its informer.
+		c.factoriesMu.Lock()
+		c.factoriesMu.Unlock()
+		c.informersRunning.Wait()
+
+		c.snapshotMu.Lock()
+		defer c.snapshotMu.Unlock()
+		for _, snapshot := range c.snapshotInformers {
+			snapshot.shutdown()
+		}
+	})
+}
+
+// WatchStatus returns the effective watch configuration and the deployment cache sync state per namespace
+func (c *kubeClient) WatchStatus() WatchStatus {
+	namespaces := c.watchedNamespaces()
+	status := WatchStatus{
+		Namespaces:        namespaces,
+		Resources:         c.watchedResources,
+		ResyncPeriod:      c.resyncPeriod,
+		ResyncPeriods:     c.resyncPeriods,
+		CacheSynced:       make(map[string]bool),
+		ClusterScoped:     c.clusterScoped(),
+		DegradedInformers: c.DegradedInformers(),
+	}
+
+	// Configured namespaces without a factory have not been initialized yet
+	for _, namespace := range namespaces {
+		status.CacheSynced[namespace] = false
+	}
+
+	for namespace, factory := range c.snapshotInformerFactories() {
+		synced := factory.Apps().V1().Deployments().Informer().HasSynced()
+		if namespace != metav1.NamespaceAll {
+			status.CacheSynced[namespace] = synced
+			continue
+		}
+		// The cluster-scoped factory serves every watched namespace
+		for _, watched := range namespaces {
+			status.CacheSynced[watched] = synced
+		}
+	}
+
+	return status
+}
+
 // GetDeploymentInformer returns the deployment informer for the given namespace
 func (c *kubeClient) GetDeploymentInformer(namespace string) (cache.SharedIndexInformer, error) {
-	factory, ok := c.informerFactories[namespace]
+	factory, ok := c.getInformerFactory(namespace)
 	if !ok {
 		return nil, fmt.Errorf("no informer factory for namespace %s", namespace)
 	}
@@ -256,3 +851,26 @@ func (c *kubeClient) GetDeploymentInformer(namespace string) (cache.SharedIndexI
 	// Return the deployment informer
 	return factory.Apps().V1().Deployments().Informer(), nil
 }
+
+// getInformerFactory returns the informer factory for the namespace, if one exists
+func (c *kubeClient) getInformerFactory(namespace string) (informers.SharedInformerFactory, bool) {
+	namespace = c.factoryNamespace(namespace)
+
+	c.factoriesMu.RLock()
+	defer c.factoriesMu.RUnlock()
+
+	factory, ok := c.informerFactories[namespace]
+	return factory, ok
+}
+
+// snapshotInformerFactories returns a copy of the informer factory map that is safe to iterate
+func (c *kubeClient) snapshotInformerFactories() map[string]informers.SharedInformerFactory {
+	c.factoriesMu.RLock()
+	defer c.factoriesMu.RUnlock()
+
+	factories := make(map[string]informers.SharedInformerFactory, len(c.informerFactories))
+	for namespace, factory := range c.informerFactories {
+		factories[namespace] = factory
+	}
+	return factories
+}
@@ -0,0 +1,852 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+
+	"k8s-controller/internal/domain"
+)
+
+// newTestClient creates a kubeClient backed by a fake clientset
+func newTestClient(objects ...runtime.Object) *kubeClient {
+	return NewClient(WithClientset(fake.NewSimpleClientset(objects...))).(*kubeClient)
+}
+
+// waitFor polls until the condition is true or the timeout expires
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestConcurrentInitializeAndList(t *testing.T) {
+	replicas := int32(1)
+	client := newTestClient(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := client.InitializeInformers(ctx, []string{"default", "other"}); err != nil {
+				t.Errorf("InitializeInformers failed: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := client.ListDeployments(ctx, "default"); err != nil {
+				t.Errorf("ListDeployments failed: %v", err)
+			}
+			_, _ = client.GetDeploymentInformer("default")
+		}()
+	}
+	wg.Wait()
+
+	deployments, err := client.ListDeployments(ctx, "default")
+	if err != nil {
+		t.Fatalf("ListDeployments failed: %v", err)
+	}
+	if len(deployments) != 1 {
+		t.Errorf("expected 1 deployment, got %d", len(deployments))
+	}
+}
+
+// recordingHandler records the events it receives and returns a fixed error
+type recordingHandler struct {
+	mu     sync.Mutex
+	events []domain.ResourceEvent
+	err    error
+}
+
+func (h *recordingHandler) HandleEvent(ctx context.Context, event domain.ResourceEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, event)
+	return h.err
+}
+
+func (h *recordingHandler) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.events)
+}
+
+func TestDispatchEventToAllHandlers(t *testing.T) {
+	client := newTestClient()
+	client.SetMaxEventRetries(0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go client.runEventWorker(ctx)
+
+	failing := &recordingHandler{err: errors.New("boom")}
+	second := &recordingHandler{}
+	client.SetEventHandler(failing)
+	client.AddEventHandler(second)
+	client.AddEventHandler(nil)
+
+	client.handleAddEvent(ctx, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+	})
+
+	waitFor(t, func() bool { return failing.count() == 1 && second.count() == 1 })
+
+	// SetEventHandler replaces previously registered handlers
+	client.SetEventHandler(second)
+	client.handleAddEvent(ctx, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+	})
+
+	waitFor(t, func() bool { return second.count() == 2 })
+	if failing.count() != 1 {
+		t.Errorf("expected replaced handler not to receive the event, got %d", failing.count())
+	}
+}
+
+func TestFailedEventIsRetriedUntilMaxRetries(t *testing.T) {
+	client := newTestClient()
+	client.SetMaxEventRetries(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go client.runEventWorker(ctx)
+
+	failing := &recordingHandler{err: errors.New("boom")}
+	client.SetEventHandler(failing)
+
+	client.handleAddEvent(ctx, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+	})
+
+	// One initial attempt plus two retries
+	waitFor(t, func() bool { return failing.count() == 3 })
+	time.Sleep(100 * time.Millisecond)
+	if failing.count() != 3 {
+		t.Errorf("expected event to be dropped after 2 retries, got %d attempts", failing.count())
+	}
+}
+
+func TestFullEventBufferDropsEvents(t *testing.T) {
+	client := newTestClient()
+	client.SetEventBuffer(1, true)
+	handler := &recordingHandler{}
+	client.SetEventHandler(handler)
+
+	// Without a running worker the first event fills the buffer and the second is dropped
+	ctx := context.Background()
+	for _, name := range []string{"first", "second"} {
+		client.handleAddEvent(ctx, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}})
+	}
+	if got := client.eventQueue.Len(); got != 1 {
+		t.Fatalf("expected 1 queued event, got %d", got)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go client.runEventWorker(ctx)
+	waitFor(t, func() bool { return handler.count() == 1 })
+
+	// Handling the event frees its place in the buffer
+	client.handleAddEvent(ctx, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "third", Namespace: "default"}})
+	waitFor(t, func() bool { return handler.count() == 2 })
+}
+
+func TestFullEventBufferBlocksUntilHandled(t *testing.T) {
+	client := newTestClient()
+	client.SetEventBuffer(1, false)
+	handler := &recordingHandler{}
+	client.SetEventHandler(handler)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client.handleAddEvent(ctx, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "default"}})
+
+	queued := make(chan struct{})
+	go func() {
+		client.handleAddEvent(ctx, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "default"}})
+		close(queued)
+	}()
+
+	select {
+	case <-queued:
+		t.Fatal("expected the second event to wait for a place in the buffer")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	go client.runEventWorker(ctx)
+	<-queued
+	waitFor(t, func() bool { return handler.count() == 2 })
+}
+
+func TestInitializeInformersWithCancelledContext(t *testing.T) {
+	client := newTestClient()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := client.InitializeInformers(ctx, []string{"default"})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	if _, err := client.GetDeploymentInformer("default"); err == nil {
+		t.Error("expected no informer factory to be registered for a cancelled context")
+	}
+}
+
+func TestWatchResourcesSharesInformerFactories(t *testing.T) {
+	replicas := int32(1)
+	client := newTestClient(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	})
+	client.SetWatchedResources([]string{"deployments"})
+
+	recorder := &recordingHandler{}
+	client.SetEventHandler(recorder)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := client.WatchResources(ctx); err != nil {
+		t.Fatalf("WatchResources failed: %v", err)
+	}
+
+	waitFor(t, func() bool { return recorder.count() == 1 })
+
+	if factories := client.snapshotInformerFactories(); len(factories) != 1 {
+		t.Errorf("expected a single shared informer factory, got %d", len(factories))
+	}
+
+	// Starting the informers again must not register duplicate handlers
+	if err := client.startInformers(ctx, []string{"default"}, []string{"deployments"}); err != nil {
+		t.Fatalf("startInformers failed: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if recorder.count() != 1 {
+		t.Errorf("expected handlers to be registered once, got %d events", recorder.count())
+	}
+}
+
+func TestListDeploymentsBySelector(t *testing.T) {
+	client := newTestClient(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web", "tier": "frontend"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", Labels: map[string]string{"app": "api", "tier": "backend"}}},
+	)
+	ctx := context.Background()
+
+	assertNames := func(selector string, want ...string) {
+		t.Helper()
+		deployments, err := client.ListDeploymentsBySelector(ctx, "default", selector)
+		if err != nil {
+			t.Fatalf("ListDeploymentsBySelector(%q) failed: %v", selector, err)
+		}
+		if len(deployments) != len(want) {
+			t.Fatalf("selector %q: expected %v, got %d deployments", selector, want, len(deployments))
+		}
+		for i, name := range want {
+			if deployments[i].Name != name {
+				t.Errorf("selector %q: expected %s at %d, got %s", selector, name, i, deployments[i].Name)
+			}
+		}
+	}
+
+	// Direct API path
+	assertNames("app=web", "web")
+	assertNames("tier in (backend)", "api")
+
+	// Informer cache path
+	if err := client.InitializeInformers(ctx, []string{"default"}); err != nil {
+		t.Fatalf("InitializeInformers failed: %v", err)
+	}
+	defer client.Stop()
+	assertNames("app!=web", "api")
+	assertNames("tier notin (backend,other)", "web")
+
+	if _, err := client.ListDeploymentsBySelector(ctx, "default", "app in (web"); err == nil {
+		t.Error("expected an error for a malformed selector")
+	}
+}
+
+func TestStopShutsDownInformerFactories(t *testing.T) {
+	client := newTestClient(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+	})
+	ctx := context.Background()
+
+	if err := client.InitializeInformers(ctx, []string{"default", "other"}); err != nil {
+		t.Fatalf("InitializeInformers failed: %v", err)
+	}
+	snapshot, err := client.NewSnapshotInformer(ctx, "reports", "", time.Hour)
+	if err != nil {
+		t.Fatalf("NewSnapshotInformer failed: %v", err)
+	}
+
+	client.Stop()
+	// A second call must be a no-op
+	client.Stop()
+
+	// Shutdown waits for informer goroutines, so every informer must already be stopped
+	for namespace, factory := range client.snapshotInformerFactories() {
+		if !factory.Apps().V1().Deployments().Informer().IsStopped() {
+			t.Errorf("deployment informer for namespace %s is still running", namespace)
+		}
+	}
+	if !snapshot.informer.IsStopped() {
+		t.Error("snapshot informer is still running")
+	}
+}
+
+func TestWithClientsetIsKeptByConnect(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	client := NewClient(WithClientset(clientset)).(*kubeClient)
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if client.clientset != clientset {
+		t.Error("expected Connect to keep the injected clientset")
+	}
+}
+
+func TestWithConfigIsUsedByConnect(t *testing.T) {
+	config := &rest.Config{Host: "https://cluster.example:6443"}
+	client := NewClient(WithConfig(config)).(*kubeClient)
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if client.metricsClientset == nil {
+		t.Error("expected Connect to create a metrics clientset")
+	}
+	host := client.clientset.CoreV1().RESTClient().Get().URL().Host
+	if host != "cluster.example:6443" {
+		t.Errorf("expected requests to go to the injected host, got %q", host)
+	}
+}
+
+func TestListDeploymentsFromInformerCache(t *testing.T) {
+	replicas := int32(2)
+	client := newTestClient(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "redis", Namespace: "default"},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "other"},
+		},
+	)
+	client.SetWatchedResources([]string{"deployments"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	defer client.Stop()
+
+	if err := client.InitializeInformers(ctx, []string{"default"}); err != nil {
+		t.Fatalf("InitializeInformers failed: %v", err)
+	}
+	informer, err := client.GetDeploymentInformer("default")
+	if err != nil {
+		t.Fatalf("GetDeploymentInformer failed: %v", err)
+	}
+	if !informer.HasSynced() {
+		t.Fatal("expected the deployment informer to be synced")
+	}
+
+	// Remove the objects from the API so only the informer cache can return them
+	clientset := client.clientset.(*fake.Clientset)
+	clientset.PrependReactor("list", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("list should be served from the cache")
+	})
+
+	deployments, err := client.ListDeployments(ctx, "default")
+	if err != nil {
+		t.Fatalf("ListDeployments failed: %v", err)
+	}
+	if len(deployments) != 2 {
+		t.Fatalf("expected 2 deployments, got %d", len(deployments))
+	}
+	for _, dep := range deployments {
+		if dep.Namespace != "default" {
+			t.Errorf("unexpected deployment %s/%s", dep.Namespace, dep.Name)
+		}
+		if dep.Name == "nginx" && dep.Replicas != 2 {
+			t.Errorf("expected nginx to have 2 replicas, got %d", dep.Replicas)
+		}
+	}
+}
+
+func TestListDeploymentsFallsBackWhenCacheNotSynced(t *testing.T) {
+	client := newTestClient(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+	})
+
+	// A factory whose informer was never started behaves like a namespace that timed out syncing
+	client.getOrCreateInformerFactory("default").Apps().V1().Deployments().Informer()
+
+	deployments, err := client.ListDeployments(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("ListDeployments failed: %v", err)
+	}
+	if len(deployments) != 1 {
+		t.Errorf("expected the API fallback to return 1 deployment, got %d", len(deployments))
+	}
+
+	if _, err := client.GetDeployment(context.Background(), "default", "nginx"); err != nil {
+		t.Errorf("GetDeployment failed: %v", err)
+	}
+}
+
+func TestGetResourceDispatchesByKind(t *testing.T) {
+	client := newTestClient(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+	)
+	ctx := context.Background()
+
+	resource, err := client.GetResource(ctx, "Deployment", "web", "default")
+	if err != nil {
+		t.Fatalf("GetResource failed: %v", err)
+	}
+	if resource.Kind != "Deployment" || resource.Name != "web" || resource.Labels["app"] != "web" {
+		t.Errorf("unexpected resource %+v", resource)
+	}
+
+	if _, err := client.GetResource(ctx, "StatefulSet", "db", "default"); err == nil {
+		t.Error("expected an error for an unsupported kind")
+	}
+}
+
+func TestClusterScopedInformerFactory(t *testing.T) {
+	client := newTestClient(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-b"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "unwatched"}},
+	)
+	client.SetNamespaces([]string{"team-a", "team-b"})
+	client.SetWatchedResources([]string{"deployments"})
+	client.SetInformerScope(1, false)
+
+	recorder := &recordingHandler{}
+	client.SetEventHandler(recorder)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := client.WatchResources(ctx); err != nil {
+		t.Fatalf("WatchResources failed: %v", err)
+	}
+
+	factories := client.snapshotInformerFactories()
+	if _, ok := factories[metav1.NamespaceAll]; !ok || len(factories) != 1 {
+		t.Fatalf("expected a single cluster-scoped factory, got %d factories", len(factories))
+	}
+
+	// Only events from watched namespaces reach the handlers
+	waitFor(t, func() bool { return recorder.count() == 2 })
+	time.Sleep(100 * time.Millisecond)
+	if recorder.count() != 2 {
+		t.Errorf("expected events from watched namespaces only, got %d", recorder.count())
+	}
+
+	// Listers still return a single namespace from the shared cache
+	deployments, err := client.ListDeployments(ctx, "team-b")
+	if err != nil {
+		t.Fatalf("ListDeployments failed: %v", err)
+	}
+	if len(deployments) != 1 || deployments[0].Name != "api" {
+		t.Errorf("expected only the team-b deployment, got %+v", deployments)
+	}
+
+	status := client.WatchStatus()
+	if !status.ClusterScoped || !status.CacheSynced["team-a"] || !status.CacheSynced["team-b"] {
+		t.Errorf("unexpected watch status %+v", status)
+	}
+}
+
+func TestPerNamespaceInformerFactoriesCanBeForced(t *testing.T) {
+	client := newTestClient()
+	client.SetNamespaces([]string{"team-a", "team-b"})
+	client.SetInformerScope(1, true)
+
+	if err := client.InitializeInformers(context.Background(), []string{"team-a", "team-b"}); err != nil {
+		t.Fatalf("InitializeInformers failed: %v", err)
+	}
+	defer client.Stop()
+
+	if factories := client.snapshotInformerFactories(); len(factories) != 2 {
+		t.Errorf("expected one factory per namespace, got %d", len(factories))
+	}
+}
+
+func TestTombstonesAreUnwrapped(t *testing.T) {
+	client := newTestClient()
+	client.SetMaxEventRetries(0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go client.runEventWorker(ctx)
+
+	recorder := &recordingHandler{}
+	client.SetEventHandler(recorder)
+
+	tombstone := cache.DeletedFinalStateUnknown{
+		Key: "default/nginx",
+		Obj: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"}},
+	}
+
+	resource := client.convertToDomainResource(tombstone)
+	if resource.Kind != "Deployment" || resource.Name != "nginx" || resource.Namespace != "default" {
+		t.Errorf("unexpected resource from tombstone %+v", resource)
+	}
+	if resource := client.convertToDomainResource(&tombstone); resource.Name != "nginx" {
+		t.Errorf("expected tombstone pointer to be unwrapped, got %+v", resource)
+	}
+
+	client.handleDeleteEvent(ctx, tombstone)
+	waitFor(t, func() bool { return recorder.count() == 1 })
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if event := recorder.events[0]; event.Type != domain.ResourceEventDeleted || event.Resource.Name != "nginx" {
+		t.Errorf("unexpected delete event %+v", event)
+	}
+}
+
+func TestWatchSelectorFiltersInformerCache(t *testing.T) {
+	client := newTestClient(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"team": "payments", "app": "web"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", Labels: map[string]string{"team": "search", "app": "api"}}},
+	)
+	client.SetWatchSelector("team=payments")
+	ctx := context.Background()
+
+	assertNames := func(selector string, want ...string) {
+		t.Helper()
+		deployments, err := client.ListDeploymentsBySelector(ctx, "default", selector)
+		if err != nil {
+			t.Fatalf("ListDeploymentsBySelector(%q) failed: %v", selector, err)
+		}
+		var names []string
+		for _, deployment := range deployments {
+			names = append(names, deployment.Name)
+		}
+		if len(names) != len(want) || (len(want) > 0 && names[0] != want[0]) {
+			t.Errorf("selector %q: expected %v, got %v", selector, want, names)
+		}
+	}
+
+	// The API fallback applies the watch selector too
+	assertNames("", "web")
+	assertNames("app=api")
+
+	// Unmatched objects never enter the informer cache
+	if err := client.InitializeInformers(ctx, []string{"default"}); err != nil {
+		t.Fatalf("InitializeInformers failed: %v", err)
+	}
+	defer client.Stop()
+	informer, err := client.GetDeploymentInformer("default")
+	if err != nil {
+		t.Fatalf("GetDeploymentInformer failed: %v", err)
+	}
+	if keys := informer.GetStore().ListKeys(); len(keys) != 1 || keys[0] != "default/web" {
+		t.Errorf("expected only default/web in the cache, got %v", keys)
+	}
+	assertNames("", "web")
+
+	if err := ValidateWatchSelector("team in (payments"); err == nil {
+		t.Error("expected an error for a malformed watch selector")
+	}
+}
+
+func TestConvertToDomainResourceOwnerReferences(t *testing.T) {
+	client := newTestClient()
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      "web-7d9c-abcde",
+		Namespace: "default",
+		OwnerReferences: []metav1.OwnerReference{
+			{Kind: "ReplicaSet", Name: "web-7d9c", UID: "rs-uid", Controller: ptr.To(true)},
+			{Kind: "ConfigMap", Name: "settings", UID: "cm-uid"},
+		},
+	}}
+
+	resource := client.convertToDomainResource(pod)
+	if len(resource.OwnerReferences) != 2 {
+		t.Fatalf("expected 2 owner references, got %+v", resource.OwnerReferences)
+	}
+	owner, ok := resource.ControllerOwner()
+	if !ok || owner.Kind != "ReplicaSet" || owner.Name != "web-7d9c" || owner.UID != "rs-uid" {
+		t.Errorf("expected the replica set as controller owner, got %+v", owner)
+	}
+	if resource.OwnerReferences[1].Controller {
+		t.Error("expected the config map owner not to be the controller")
+	}
+
+	if resource := client.convertToDomainResource(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "bare"}}); resource.OwnerReferences != nil {
+		t.Errorf("expected no owner references for a bare pod, got %+v", resource.OwnerReferences)
+	}
+}
+
+func TestResourceSelectorFiltersOnlyItsInformer(t *testing.T) {
+	client := newTestClient(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"team": "payments"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", Labels: map[string]string{"team": "search"}}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"team": "payments"}}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", Labels: map[string]string{"team": "search"}}},
+	)
+	client.SetResourceSelectors(map[string]string{"deploy": "team=payments"})
+	ctx := context.Background()
+
+	// The API fallback applies the selector to its resource only
+	deployments, err := client.listObjects(ctx, "deployments", "default", "")
+	if err != nil {
+		t.Fatalf("listing deployments failed: %v", err)
+	}
+	if len(deployments) != 1 {
+		t.Errorf("expected only the payments deployment from the API, got %d", len(deployments))
+	}
+
+	factory := client.getOrCreateInformerFactory("default")
+	for _, resource := range []string{"deployments", "services"} {
+		client.createInformer(factory, "default", mustResourceType(t, resource))
+	}
+	defer client.Stop()
+	waitFor(t, func() bool {
+		_, deploymentsSynced := client.syncedInformer("default", mustResourceType(t, "deployments"))
+		_, servicesSynced := client.syncedInformer("default", mustResourceType(t, "services"))
+		return deploymentsSynced && servicesSynced
+	})
+
+	informer, _ := client.syncedInformer("default", mustResourceType(t, "deployments"))
+	if keys := informer.GetStore().ListKeys(); len(keys) != 1 || keys[0] != "default/web" {
+		t.Errorf("expected only default/web in the deployment cache, got %v", keys)
+	}
+	informer, _ = client.syncedInformer("default", mustResourceType(t, "services"))
+	if keys := informer.GetStore().ListKeys(); len(keys) != 2 {
+		t.Errorf("expected both services in the service cache, got %v", keys)
+	}
+}
+
+// mustResourceType looks up a supported resource type
+func mustResourceType(t *testing.T, resource string) resourceType {
+	t.Helper()
+	rt, err := mustLookupResourceType(resource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rt
+}
+
+func TestForbiddenInformerIsDegraded(t *testing.T) {
+	client := newTestClient(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+	)
+	listCalls := 0
+	var listMu sync.Mutex
+	client.clientset.(*fake.Clientset).PrependReactor("list", "services", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		listMu.Lock()
+		defer listMu.Unlock()
+		listCalls++
+		return true, nil, apierrors.NewForbidden(corev1.Resource("services"), "", errors.New("no RBAC"))
+	})
+	client.SetWatchedResources([]string{"deployments", "services"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := client.WatchResources(ctx); err != nil {
+		t.Fatalf("WatchResources failed: %v", err)
+	}
+	defer client.Stop()
+
+	waitFor(t, func() bool { return len(client.WatchStatus().DegradedInformers) == 1 })
+	if _, ok := client.WatchStatus().DegradedInformers["default/services"]; !ok {
+		t.Errorf("expected default/services to be degraded, got %v", client.WatchStatus().DegradedInformers)
+	}
+
+	// The forbidden informer stops retrying
+	services, _ := lookupResourceType("services")
+	factory, _ := client.getInformerFactory("default")
+	waitFor(t, func() bool { return services.informer(factory).IsStopped() })
+	listMu.Lock()
+	calls := listCalls
+	listMu.Unlock()
+	time.Sleep(200 * time.Millisecond)
+	listMu.Lock()
+	defer listMu.Unlock()
+	if listCalls != calls {
+		t.Errorf("expected no more list calls after the informer stopped, got %d more", listCalls-calls)
+	}
+
+	// Resources that can be watched are still served from the cache
+	deployments, _ := lookupResourceType("deployments")
+	waitFor(t, func() bool {
+		_, synced := client.syncedInformer("default", deployments)
+		return synced
+	})
+}
+
+func TestStartInformerLeavesOtherPendingInformersStopped(t *testing.T) {
+	client := newTestClient(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}},
+	)
+	defer client.Stop()
+
+	// An informer requested from the factory but not started by the client
+	factory := client.getOrCreateInformerFactory("default")
+	pods := factory.Core().V1().Pods().Informer()
+
+	deployments, _ := lookupResourceType("deployments")
+	deployInformer := client.createInformer(factory, "default", deployments)
+	waitFor(t, deployInformer.HasSynced)
+
+	// Stopping the deployment informer, as a forbidden error does, must not affect the other one
+	client.factoriesMu.RLock()
+	stop := client.informerStops["default/deployments"]
+	client.factoriesMu.RUnlock()
+	stop.close()
+	waitFor(t, deployInformer.IsStopped)
+
+	time.Sleep(100 * time.Millisecond)
+	if pods.HasSynced() || len(pods.GetStore().List()) != 0 {
+		t.Error("expected the pending pod informer not to be started with the deployment informer")
+	}
+}
+
+func TestToDomainDeploymentTimestampsInUTC(t *testing.T) {
+	created := time.Date(2025, 3, 4, 10, 30, 0, 0, time.FixedZone("CET", 3600))
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", CreationTimestamp: metav1.NewTime(created)}}
+
+	got := ToDomainDeployment(deployment)
+	if got.CreationTimestamp != "2025-03-04T09:30:00Z" {
+		t.Errorf("expected an RFC3339 UTC creation timestamp, got %q", got.CreationTimestamp)
+	}
+	if got.CreatedAt.Location() != time.UTC || !got.CreatedAt.Equal(created) {
+		t.Errorf("expected CreatedAt %v in UTC, got %v", created, got.CreatedAt)
+	}
+}
+
+// blockingHandler blocks every event until release is closed
+type blockingHandler struct {
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+	ctxErr  error
+}
+
+func (h *blockingHandler) HandleEvent(ctx context.Context, event domain.ResourceEvent) error {
+	h.once.Do(func() { close(h.started) })
+	<-h.release
+	h.ctxErr = ctx.Err()
+	return nil
+}
+
+func TestEventsDrainedWaitsForInFlightEvents(t *testing.T) {
+	client := newTestClient(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}})
+	client.SetNamespaces([]string{"default"})
+	client.SetWatchedResources([]string{"deployments"})
+	handler := &blockingHandler{started: make(chan struct{}), release: make(chan struct{})}
+	client.SetEventHandler(handler)
+
+	select {
+	case <-client.EventsDrained():
+	default:
+		t.Fatal("expected EventsDrained to be closed before the worker is started")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := client.WatchResources(ctx); err != nil {
+		t.Fatalf("WatchResources failed: %v", err)
+	}
+	<-handler.started
+	cancel()
+
+	select {
+	case <-client.EventsDrained():
+		t.Fatal("expected EventsDrained to wait for the in-flight event")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(handler.release)
+	select {
+	case <-client.EventsDrained():
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the event worker to exit")
+	}
+	if handler.ctxErr != nil {
+		t.Errorf("expected the handler context to outlive the watch, got %v", handler.ctxErr)
+	}
+}
+
+// Run with -race: discovery replaces the namespaces while handlers and status requests read them
+func TestConcurrentNamespaceDiscoveryAndReads(t *testing.T) {
+	client := newTestClient(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-c"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}},
+	)
+	client.SetWatchedResources([]string{"deployments"})
+	client.SetDiscoverNamespaces(true)
+	client.SetInformerScope(1, false)
+	client.SetEventHandler(&recordingHandler{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}}
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				_ = client.WatchStatus()
+				_ = client.isWatchedNamespace(deployment)
+				_, _ = client.ListWatchedDeployments(ctx)
+			}
+		}()
+	}
+	if err := client.WatchResources(ctx); err != nil {
+		t.Fatalf("WatchResources failed: %v", err)
+	}
+	wg.Wait()
+
+	status := client.WatchStatus()
+	if len(status.Namespaces) != 3 || !status.ClusterScoped {
+		t.Errorf("expected three discovered namespaces behind one cluster-scoped factory, got %+v", status)
+	}
+
+	// Namespaces set after the first factory exists don't switch the factory layout
+	client.SetNamespaces([]string{"team-a"})
+	if !client.clusterScoped() {
+		t.Error("expected the informer scope to stay cluster-scoped once factories exist")
+	}
+}
@@ -0,0 +1,100 @@
+package kubernetes
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+)
+
+// informerStop stops a single informer, either with the client or when it is degraded
+type informerStop struct {
+	ch   chan struct{}
+	once sync.Once
+}
+
+// close closes the stop channel, it is safe to call more than once
+func (s *informerStop) close() {
+	s.once.Do(func() { close(s.ch) })
+}
+
+// startInformer runs the informer stored under key on its own stop channel, so a forbidden
+// informer can be stopped without stopping the rest of its factory. It runs the informer
+// directly rather than through the factory, which would start every pending informer of the
+// factory on this channel. The channel is closed when the client stops.
+func (c *kubeClient) startInformer(key string, informer cache.SharedIndexInformer) {
+	c.factoriesMu.Lock()
+	if _, ok := c.informerStops[key]; ok {
+		c.factoriesMu.Unlock()
+		return
+	}
+	// A stopped client starts no informers; adding to informersRunning under the lock keeps
+	// the count from growing once Stop waits on it
+	select {
+	case <-c.stopCh:
+		c.factoriesMu.Unlock()
+		return
+	default:
+	}
+	stop := &informerStop{ch: make(chan struct{})}
+	c.informerStops[key] = stop
+	c.informersRunning.Add(1)
+	c.factoriesMu.Unlock()
+
+	if err := informer.SetWatchErrorHandlerWithContext(c.watchErrorHandler(key, stop)); err != nil {
+		slog.Debug("Informer already started, keeping the default watch error handler", "informer", key, "error", err)
+	}
+
+	go func() {
+		select {
+		case <-c.stopCh:
+			stop.close()
+		case <-stop.ch:
+		}
+	}()
+
+	go func() {
+		defer c.informersRunning.Done()
+		informer.Run(stop.ch)
+	}()
+}
+
+// watchErrorHandler returns a watch error handler that stops the informer on forbidden errors.
+// Retrying cannot succeed until RBAC changes, so the informer is marked degraded and logged
+// once instead of failing in a loop. Other errors keep the default handling and backoff.
+func (c *kubeClient) watchErrorHandler(key string, stop *informerStop) cache.WatchErrorHandlerWithContext {
+	return func(ctx context.Context, r *cache.Reflector, err error) {
+		if !apierrors.IsForbidden(err) {
+			cache.DefaultWatchErrorHandler(ctx, r, err)
+			return
+		}
+
+		c.factoriesMu.Lock()
+		_, degraded := c.degradedInformers[key]
+		if !degraded {
+			c.degradedInformers[key] = err.Error()
+		}
+		c.factoriesMu.Unlock()
+
+		if !degraded {
+			slog.Error("Informer is forbidden to list or watch, stopping it; other resources are still served",
+				"informer", key, "error", err)
+		}
+		stop.close()
+	}
+}
+
+// DegradedInformers returns the informers stopped because of forbidden errors, keyed by
+// namespace/resource, with the error that stopped them
+func (c *kubeClient) DegradedInformers() map[string]string {
+	c.factoriesMu.RLock()
+	defer c.factoriesMu.RUnlock()
+
+	degraded := make(map[string]string, len(c.degradedInformers))
+	for key, reason := range c.degradedInformers {
+		degraded[key] = reason
+	}
+	return degraded
+}
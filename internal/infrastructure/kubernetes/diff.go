@@ -0,0 +1,71 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"sigs.k8s.io/yaml"
+)
+
+// DiffManifest returns a unified diff from the live object to the desired manifest, or an
+// empty string if they match. Only fields set in the manifest are compared, so defaults and
+// fields filled in by the cluster don't show up as drift. live may be nil for a missing object.
+func DiffManifest(desired, live map[string]interface{}, name string) (string, error) {
+	var liveYAML []byte
+	if live != nil {
+		pruned, _ := pruneToFields(live, desired).(map[string]interface{})
+		out, err := yaml.Marshal(pruned)
+		if err != nil {
+			return "", fmt.Errorf("failed to render live %s: %w", name, err)
+		}
+		liveYAML = out
+	}
+
+	desiredYAML, err := yaml.Marshal(desired)
+	if err != nil {
+		return "", fmt.Errorf("failed to render desired %s: %w", name, err)
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(liveYAML)),
+		B:        difflib.SplitLines(string(desiredYAML)),
+		FromFile: "live/" + name,
+		ToFile:   "desired/" + name,
+		Context:  3,
+	})
+}
+
+// pruneToFields returns live restricted to the map keys present in desired. List items are
+// pruned by position and extra live items are kept, so added or removed items still differ.
+func pruneToFields(live, desired interface{}) interface{} {
+	switch desiredVal := desired.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		pruned := make(map[string]interface{}, len(desiredVal))
+		for key, value := range desiredVal {
+			if liveValue, ok := liveMap[key]; ok {
+				pruned[key] = pruneToFields(liveValue, value)
+			}
+		}
+		return pruned
+	case []interface{}:
+		liveList, ok := live.([]interface{})
+		if !ok {
+			return live
+		}
+		pruned := make([]interface{}, len(liveList))
+		for i, item := range liveList {
+			if i < len(desiredVal) {
+				pruned[i] = pruneToFields(item, desiredVal[i])
+			} else {
+				pruned[i] = item
+			}
+		}
+		return pruned
+	default:
+		return live
+	}
+}
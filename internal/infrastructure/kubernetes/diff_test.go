@@ -0,0 +1,69 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiffManifestComparesOnlyManifestFields(t *testing.T) {
+	replicas := int32(2)
+	client := newTestClient(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", ResourceVersion: "7", Labels: map[string]string{"app": "web"}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "web", Image: "nginx:1.27", TerminationMessagePath: "/dev/termination-log"},
+			}}},
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 2},
+	})
+
+	resource, err := client.GetResource(context.Background(), "Deployment", "web", "default")
+	if err != nil {
+		t.Fatalf("GetResource failed: %v", err)
+	}
+
+	// JSON numbers decode as float64, like manifests read from a file
+	desired := func(replicas float64, image string) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "web", "labels": map[string]interface{}{"app": "web"}},
+			"spec": map[string]interface{}{
+				"replicas": replicas,
+				"template": map[string]interface{}{"spec": map[string]interface{}{
+					"containers": []interface{}{map[string]interface{}{"name": "web", "image": image}},
+				}},
+			},
+		}
+	}
+
+	diff, err := DiffManifest(desired(2, "nginx:1.27"), resource.Data, "web")
+	if err != nil {
+		t.Fatalf("DiffManifest failed: %v", err)
+	}
+	if diff != "" {
+		t.Errorf("expected no diff when only defaults differ, got:\n%s", diff)
+	}
+
+	diff, err = DiffManifest(desired(3, "nginx:1.28"), resource.Data, "web")
+	if err != nil {
+		t.Fatalf("DiffManifest failed: %v", err)
+	}
+	for _, want := range []string{"-  replicas: 2", "+  replicas: 3", "-      - image: nginx:1.27", "+      - image: nginx:1.28"} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("diff does not contain %q:\n%s", want, diff)
+		}
+	}
+
+	// A missing live object shows the whole manifest as added
+	diff, _ = DiffManifest(desired(2, "nginx:1.27"), nil, "web")
+	if !strings.Contains(diff, "+kind: Deployment") {
+		t.Errorf("expected an all-added diff, got:\n%s", diff)
+	}
+}
@@ -0,0 +1,109 @@
+//go:build integration
+
+package kubernetes
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	"k8s-controller/internal/domain"
+)
+
+// startEnvtest starts a local control plane for the test, skipping it when the envtest
+// binaries are not installed. Run `make test-integration` to download them.
+func startEnvtest(t *testing.T) *rest.Config {
+	t.Helper()
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		t.Skip("KUBEBUILDER_ASSETS is not set, skipping envtest integration test")
+	}
+
+	env := &envtest.Environment{}
+	cfg, err := env.Start()
+	if err != nil {
+		t.Fatalf("failed to start envtest: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := env.Stop(); err != nil {
+			t.Errorf("failed to stop envtest: %v", err)
+		}
+	})
+	return cfg
+}
+
+// testDeployment returns a minimal valid deployment
+func testDeployment(name string) *appsv1.Deployment {
+	replicas := int32(1)
+	labels := map[string]string{"app": name}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: name, Image: "nginx:1.27"}}},
+			},
+		},
+	}
+}
+
+func TestEnvtestWatchAndListDeployments(t *testing.T) {
+	cfg := startEnvtest(t)
+
+	client := NewClient(WithConfig(cfg)).(*kubeClient)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	clientset := client.clientset
+	client.SetWatchedResources([]string{"deployments"})
+
+	recorder := &recordingHandler{}
+	client.SetEventHandler(recorder)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := client.WatchResources(ctx); err != nil {
+		t.Fatalf("WatchResources failed: %v", err)
+	}
+	defer client.Stop()
+
+	deployments := clientset.AppsV1().Deployments("default")
+	if _, err := deployments.Create(ctx, testDeployment("web"), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create deployment: %v", err)
+	}
+
+	// The informer delivers the create event and the cache serves the deployment
+	waitFor(t, func() bool { return recorder.hasEvent(domain.ResourceEventCreated, "web") })
+	waitFor(t, func() bool {
+		listed, err := client.ListDeployments(ctx, "default")
+		return err == nil && len(listed) == 1 && listed[0].Name == "web"
+	})
+
+	if err := deployments.Delete(ctx, "web", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete deployment: %v", err)
+	}
+	waitFor(t, func() bool { return recorder.hasEvent(domain.ResourceEventDeleted, "web") })
+	waitFor(t, func() bool {
+		listed, err := client.ListDeployments(ctx, "default")
+		return err == nil && len(listed) == 0
+	})
+}
+
+// hasEvent reports whether an event of the type was recorded for the named resource
+func (h *recordingHandler) hasEvent(eventType domain.ResourceEventType, name string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, event := range h.events {
+		if event.Type == eventType && event.Resource.Name == name {
+			return true
+		}
+	}
+	return false
+}
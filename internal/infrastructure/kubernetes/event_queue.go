@@ -0,0 +1,152 @@
+package kubernetes
+
+import (
+	"context"
+	"log/slog"
+
+	"k8s.io/client-go/util/workqueue"
+
+	"k8s-controller/internal/domain"
+	"k8s-controller/internal/infrastructure/metrics"
+)
+
+// defaultMaxEventRetries is the number of times a failed event is retried before it is dropped
+const defaultMaxEventRetries = 5
+
+// queuedEvent is a resource event waiting to be delivered to a single handler.
+// Items are queued by pointer so each delivery is tracked independently by the rate limiter.
+type queuedEvent struct {
+	event   domain.ResourceEvent
+	handler ResourceEventHandler
+}
+
+// newEventQueue creates a rate-limited queue for resource event deliveries
+func newEventQueue() workqueue.TypedRateLimitingInterface[*queuedEvent] {
+	return workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.DefaultTypedControllerRateLimiter[*queuedEvent](),
+		workqueue.TypedRateLimitingQueueConfig[*queuedEvent]{Name: "resource-events"},
+	)
+}
+
+// runEventWorker delivers queued events until the context is cancelled, then delivers the events
+// still queued and returns. Handlers get a context that isn't cancelled, so in-flight and queued
+// events complete during shutdown; failed events are not retried once the queue is shut down.
+func (c *kubeClient) runEventWorker(ctx context.Context) {
+	go func() {
+		<-ctx.Done()
+		c.eventQueue.ShutDown()
+	}()
+
+	deliverCtx := context.WithoutCancel(ctx)
+	for c.processNextEvent(deliverCtx) {
+	}
+}
+
+// startEventWorker runs the event worker in a goroutine whose exit closes the EventsDrained channel
+func (c *kubeClient) startEventWorker(ctx context.Context) {
+	done := make(chan struct{})
+	c.workerMu.Lock()
+	c.workerDone = done
+	c.workerMu.Unlock()
+
+	go func() {
+		defer close(done)
+		c.runEventWorker(ctx)
+	}()
+}
+
+// EventsDrained returns a channel that is closed once the event worker started by WatchResources
+// has delivered the queued events and exited after its context was cancelled. It is closed right
+// away when no worker was started.
+func (c *kubeClient) EventsDrained() <-chan struct{} {
+	c.workerMu.Lock()
+	defer c.workerMu.Unlock()
+	if c.workerDone == nil {
+		closed := make(chan struct{})
+		close(closed)
+		return closed
+	}
+	return c.workerDone
+}
+
+// processNextEvent delivers one queued event, re-queuing it with backoff on failure.
+// It returns false once the queue has been shut down.
+func (c *kubeClient) processNextEvent(ctx context.Context) bool {
+	item, shutdown := c.eventQueue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.eventQueue.Done(item)
+
+	err := item.handler.HandleEvent(ctx, item.event)
+	if err == nil {
+		c.eventQueue.Forget(item)
+		c.releaseEventBuffer()
+		return true
+	}
+
+	retries := c.eventQueue.NumRequeues(item)
+	if retries < c.maxEventRetries {
+		slog.Warn("Failed to handle event, retrying",
+			"type", item.event.Type,
+			"kind", item.event.Resource.Kind,
+			"name", item.event.Resource.Name,
+			"namespace", item.event.Resource.Namespace,
+			"retry", retries+1,
+			"error", err)
+		c.eventQueue.AddRateLimited(item)
+		return true
+	}
+
+	slog.Error("Giving up on event after max retries",
+		"type", item.event.Type,
+		"kind", item.event.Resource.Kind,
+		"name", item.event.Resource.Name,
+		"namespace", item.event.Resource.Namespace,
+		"retries", retries,
+		"error", err)
+	metrics.EventsDropped.WithLabelValues(item.event.Resource.Kind, string(item.event.Type)).Inc()
+	c.eventQueue.Forget(item)
+	c.releaseEventBuffer()
+	return true
+}
+
+// reserveEventBuffer takes a place in the event buffer for one delivery of the event. When the
+// buffer is full it either drops the event, counting it, or waits for the worker to free a place.
+// It returns false if the event must not be queued.
+func (c *kubeClient) reserveEventBuffer(event domain.ResourceEvent) bool {
+	if c.eventBuffer == nil {
+		return true
+	}
+
+	if c.dropWhenFull {
+		select {
+		case c.eventBuffer <- struct{}{}:
+			return true
+		default:
+			slog.Warn("Event buffer full, dropping event",
+				"type", event.Type,
+				"kind", event.Resource.Kind,
+				"name", event.Resource.Name,
+				"namespace", event.Resource.Namespace,
+				"size", cap(c.eventBuffer))
+			metrics.EventsBufferDropped.WithLabelValues(event.Resource.Kind, string(event.Type)).Inc()
+			return false
+		}
+	}
+
+	select {
+	case c.eventBuffer <- struct{}{}:
+		return true
+	case <-c.stopCh:
+		return false
+	}
+}
+
+// releaseEventBuffer frees the place of a delivery that succeeded or was given up.
+// Retried deliveries keep their place until then.
+func (c *kubeClient) releaseEventBuffer() {
+	if c.eventBuffer != nil {
+		<-c.eventBuffer
+	}
+}
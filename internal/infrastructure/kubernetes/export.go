@@ -0,0 +1,162 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// exportableResources are the resource types that can be exported. Pods are left out
+// because they are created by their controllers.
+var exportableResources = []string{"deployments", "services", "configmaps"}
+
+// serverManagedMetadata are metadata fields set by the API server that must not be re-applied
+var serverManagedMetadata = []string{
+	"uid", "resourceVersion", "generation", "creationTimestamp", "deletionTimestamp",
+	"deletionGracePeriodSeconds", "managedFields", "selfLink",
+}
+
+// serverManagedAnnotations are annotations written by the API server or kubectl
+var serverManagedAnnotations = []string{
+	"deployment.kubernetes.io/revision",
+	"kubectl.kubernetes.io/last-applied-configuration",
+}
+
+// serverManagedSpecFields are spec fields allocated by the cluster, per resource
+var serverManagedSpecFields = map[string][]string{
+	// Cluster IPs are allocated from the service CIDR and usually differ between clusters
+	"services": {"clusterIP", "clusterIPs"},
+}
+
+// skippedExports are objects created automatically in every namespace
+var skippedExports = map[string]map[string]bool{
+	"configmaps": {"kube-root-ca.crt": true},
+}
+
+// ExportManifests lists the resources in the namespace and renders them as a multi-document
+// YAML manifest with server-managed fields removed, so the output can be applied again.
+// Documents are ordered by resource type as given, then by name.
+func (c *kubeClient) ExportManifests(ctx context.Context, namespace string, resources []string) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, resource := range resources {
+		rt, err := exportableResourceType(resource)
+		if err != nil {
+			return nil, err
+		}
+
+		objects, err := c.listObjects(ctx, rt.Resource, namespace, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", rt.Resource, err)
+		}
+
+		manifests := make([]map[string]interface{}, 0, len(objects))
+		for _, obj := range objects {
+			if metaObj, ok := obj.(metav1.Object); ok && skippedExports[rt.Resource][metaObj.GetName()] {
+				continue
+			}
+			manifest, err := exportManifest(rt, obj)
+			if err != nil {
+				return nil, err
+			}
+			manifests = append(manifests, manifest)
+		}
+		sort.Slice(manifests, func(i, j int) bool {
+			return manifestName(manifests[i]) < manifestName(manifests[j])
+		})
+
+		for _, manifest := range manifests {
+			out, err := yaml.Marshal(manifest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render %s %s: %w", rt.Kind, manifestName(manifest), err)
+			}
+			buf.WriteString("---\n")
+			buf.Write(out)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// exportableResourceType looks up a resource type that can be exported
+func exportableResourceType(resource string) (resourceType, error) {
+	rt, err := mustLookupResourceType(resource)
+	if err != nil {
+		return resourceType{}, err
+	}
+	for _, exportable := range exportableResources {
+		if rt.Resource == exportable {
+			return rt, nil
+		}
+	}
+	return resourceType{}, fmt.Errorf("resource type %q cannot be exported (supported: %v)", resource, exportableResources)
+}
+
+// exportManifest converts the object to a map with type meta set and server-managed fields removed
+func exportManifest(rt resourceType, obj runtime.Object) (map[string]interface{}, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s: %w", rt.Kind, err)
+	}
+
+	// Objects from the informer cache have no type meta
+	content["apiVersion"] = rt.APIVersion()
+	content["kind"] = rt.Kind
+	stripServerManagedFields(rt, content)
+	return content, nil
+}
+
+// stripServerManagedFields removes status and every field the API server sets or allocates
+func stripServerManagedFields(rt resourceType, content map[string]interface{}) {
+	delete(content, "status")
+
+	for _, field := range serverManagedMetadata {
+		unstructured.RemoveNestedField(content, "metadata", field)
+	}
+	for _, annotation := range serverManagedAnnotations {
+		unstructured.RemoveNestedField(content, "metadata", "annotations", annotation)
+	}
+	if annotations, found, _ := unstructured.NestedMap(content, "metadata", "annotations"); found && len(annotations) == 0 {
+		unstructured.RemoveNestedField(content, "metadata", "annotations")
+	}
+
+	// Headless services declare clusterIP: None, which must be kept for them to stay headless
+	if !isHeadlessService(rt, content) {
+		for _, field := range serverManagedSpecFields[rt.Resource] {
+			unstructured.RemoveNestedField(content, "spec", field)
+		}
+	}
+
+	// Deployment pod templates carry an empty creationTimestamp after conversion
+	unstructured.RemoveNestedField(content, "spec", "template", "metadata", "creationTimestamp")
+}
+
+// isHeadlessService reports whether the content is a service with clusterIP None
+func isHeadlessService(rt resourceType, content map[string]interface{}) bool {
+	clusterIP, _, _ := unstructured.NestedString(content, "spec", "clusterIP")
+	return rt.Resource == "services" && clusterIP == corev1.ClusterIPNone
+}
+
+// manifestName returns metadata.name of the manifest
+func manifestName(manifest map[string]interface{}) string {
+	name, _, _ := unstructured.NestedString(manifest, "metadata", "name")
+	return name
+}
+
+// NormalizeManifest removes status and server-managed fields from a manifest of a supported
+// kind, the same way they are removed from exported and fetched objects
+func NormalizeManifest(manifest map[string]interface{}) error {
+	kind, _, _ := unstructured.NestedString(manifest, "kind")
+	rt, err := mustLookupResourceType(kind)
+	if err != nil {
+		return err
+	}
+	stripServerManagedFields(rt, manifest)
+	return nil
+}
@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+func TestExportManifestsStripsServerManagedFields(t *testing.T) {
+	replicas := int32(2)
+	serverMeta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			UID:               types.UID("0a1b2c"),
+			ResourceVersion:   "42",
+			Generation:        3,
+			CreationTimestamp: metav1.Now(),
+			ManagedFields:     []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+			Labels:            map[string]string{"app": name},
+			Annotations:       map[string]string{"deployment.kubernetes.io/revision": "3"},
+		}
+	}
+	client := newTestClient(
+		&appsv1.Deployment{
+			ObjectMeta: serverMeta("web"),
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: "nginx:1.27"}}}},
+			},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: 2},
+		},
+		&corev1.Service{ObjectMeta: serverMeta("web"), Spec: corev1.ServiceSpec{ClusterIP: "10.0.0.1", ClusterIPs: []string{"10.0.0.1"}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kube-root-ca.crt", Namespace: "default"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"}, Data: map[string]string{"mode": "fast"}},
+	)
+
+	out, err := client.ExportManifests(context.Background(), "default", []string{"deployments", "services", "configmaps"})
+	if err != nil {
+		t.Fatalf("ExportManifests failed: %v", err)
+	}
+
+	documents := strings.Split(strings.TrimPrefix(string(out), "---\n"), "---\n")
+	if len(documents) != 3 {
+		t.Fatalf("expected 3 documents, got %d:\n%s", len(documents), out)
+	}
+
+	for _, field := range []string{"status", "uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "deployment.kubernetes.io/revision", "clusterIP", "kube-root-ca.crt"} {
+		if strings.Contains(string(out), field) {
+			t.Errorf("export still contains %s:\n%s", field, out)
+		}
+	}
+
+	var deployment appsv1.Deployment
+	if err := yaml.UnmarshalStrict([]byte(documents[0]), &deployment); err != nil {
+		t.Fatalf("deployment document does not parse: %v", err)
+	}
+	if deployment.APIVersion != "apps/v1" || deployment.Kind != "Deployment" || *deployment.Spec.Replicas != 2 ||
+		deployment.Spec.Template.Spec.Containers[0].Image != "nginx:1.27" || deployment.Labels["app"] != "web" {
+		t.Errorf("unexpected deployment manifest %+v", deployment)
+	}
+
+	if _, err := client.ExportManifests(context.Background(), "default", []string{"pods"}); err == nil {
+		t.Error("expected pods not to be exportable")
+	}
+}
+
+func TestExportManifestsKeepsHeadlessClusterIP(t *testing.T) {
+	client := newTestClient(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone, ClusterIPs: []string{corev1.ClusterIPNone}},
+	})
+
+	out, err := client.ExportManifests(context.Background(), "default", []string{"services"})
+	if err != nil {
+		t.Fatalf("ExportManifests failed: %v", err)
+	}
+
+	var service corev1.Service
+	if err := yaml.UnmarshalStrict([]byte(strings.TrimPrefix(string(out), "---\n")), &service); err != nil {
+		t.Fatalf("service document does not parse: %v", err)
+	}
+	if service.Spec.ClusterIP != corev1.ClusterIPNone || len(service.Spec.ClusterIPs) != 1 || service.Spec.ClusterIPs[0] != corev1.ClusterIPNone {
+		t.Errorf("expected the headless service to keep clusterIP None, got %q %q", service.Spec.ClusterIP, service.Spec.ClusterIPs)
+	}
+}
@@ -0,0 +1,42 @@
+package kubernetes
+
+import (
+	"context"
+	"log/slog"
+
+	"k8s-controller/internal/domain"
+)
+
+// EventTypeFilter forwards only events of selected types to the wrapped handler
+type EventTypeFilter struct {
+	handler ResourceEventHandler
+	types   map[domain.ResourceEventType]bool
+}
+
+// NewEventTypeFilter creates a handler that only forwards events whose type is in eventTypes.
+// Event type names are case-insensitive. An empty list forwards all events.
+func NewEventTypeFilter(handler ResourceEventHandler, eventTypes ...string) *EventTypeFilter {
+	types := make(map[domain.ResourceEventType]bool, len(eventTypes))
+	for _, name := range eventTypes {
+		eventType, err := domain.ParseEventType(name)
+		if err != nil {
+			slog.Warn("Ignoring unknown event type in filter", "type", name)
+			continue
+		}
+		types[eventType] = true
+	}
+
+	return &EventTypeFilter{
+		handler: handler,
+		types:   types,
+	}
+}
+
+// HandleEvent forwards the event if its type is allowed by the filter
+func (f *EventTypeFilter) HandleEvent(ctx context.Context, event domain.ResourceEvent) error {
+	if len(f.types) > 0 && !f.types[event.Type] {
+		slog.Debug("Skipping filtered event", "type", event.Type, "kind", event.Resource.Kind, "name", event.Resource.Name)
+		return nil
+	}
+	return f.handler.HandleEvent(ctx, event)
+}
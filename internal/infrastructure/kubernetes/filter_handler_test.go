@@ -0,0 +1,34 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"k8s-controller/internal/domain"
+)
+
+func TestEventTypeFilter(t *testing.T) {
+	recorder := &recordingHandler{}
+	filter := NewEventTypeFilter(recorder, "deleted")
+
+	for _, eventType := range []domain.ResourceEventType{
+		domain.ResourceEventCreated,
+		domain.ResourceEventUpdated,
+		domain.ResourceEventDeleted,
+	} {
+		if err := filter.HandleEvent(context.Background(), domain.ResourceEvent{Type: eventType}); err != nil {
+			t.Fatalf("HandleEvent failed: %v", err)
+		}
+	}
+
+	if recorder.count() != 1 || recorder.events[0].Type != domain.ResourceEventDeleted {
+		t.Errorf("expected only the DELETED event to be forwarded, got %v", recorder.events)
+	}
+
+	// An empty filter forwards everything
+	all := &recordingHandler{}
+	_ = NewEventTypeFilter(all).HandleEvent(context.Background(), domain.ResourceEvent{Type: domain.ResourceEventCreated})
+	if all.count() != 1 {
+		t.Errorf("expected empty filter to forward all events, got %d", all.count())
+	}
+}
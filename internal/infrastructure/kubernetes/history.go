@@ -0,0 +1,181 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"k8s-controller/internal/domain"
+)
+
+// Annotations set on ReplicaSets by the deployment controller and kubectl
+const (
+	revisionAnnotation    = "deployment.kubernetes.io/revision"
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// ErrRevisionNotFound is returned by RollbackDeployment when the deployment has no ReplicaSet
+// with the requested revision
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// ListDeploymentHistory returns the rollout history of a deployment, like kubectl rollout history.
+// Each revision is a ReplicaSet controlled by the deployment; the newest revision comes first.
+func (c *kubeClient) ListDeploymentHistory(ctx context.Context, namespace, name string) ([]domain.DeploymentRevision, error) {
+	loggerFor(ctx).Debug("Listing deployment history", "name", name, "namespace", namespace)
+
+	obj, err := c.getObject(ctx, "deployments", namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	replicaSets, err := c.ownedReplicaSets(ctx, obj.(*appsv1.Deployment))
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]domain.DeploymentRevision, 0, len(replicaSets))
+	for _, rs := range replicaSets {
+		revisions = append(revisions, toDomainRevision(rs))
+	}
+
+	loggerFor(ctx).Info("Successfully listed deployment history", "count", len(revisions), "name", name, "namespace", namespace)
+	return revisions, nil
+}
+
+// RollbackDeployment restores the pod template of the ReplicaSet with the given revision, like
+// kubectl rollout undo. Revision 0 rolls back to the revision before the current one. It returns
+// the revision rolled back to, or an error wrapping ErrRevisionNotFound if there is no such revision.
+func (c *kubeClient) RollbackDeployment(ctx context.Context, namespace, name string, revision int64) (domain.DeploymentRevision, error) {
+	if c.clientset == nil {
+		return domain.DeploymentRevision{}, fmt.Errorf("kubernetes client not connected")
+	}
+
+	var target domain.DeploymentRevision
+	deployments := c.clientset.AppsV1().Deployments(namespace)
+	defer c.invalidateListCache("deployments", namespace)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		deployment, err := deployments.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		replicaSets, err := c.ownedReplicaSets(ctx, deployment)
+		if err != nil {
+			return err
+		}
+
+		rs, err := findRevision(replicaSets, revision)
+		if err != nil {
+			return err
+		}
+		target = toDomainRevision(rs)
+
+		// The pod-template-hash label is added by the deployment controller and must not be copied back
+		template := rs.Spec.Template.DeepCopy()
+		delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+		if apiequality.Semantic.DeepEqual(deployment.Spec.Template, *template) {
+			loggerFor(ctx).Info("Deployment already runs the requested revision, skipping rollback", "name", name, "namespace", namespace, "revision", target.Revision)
+			return nil
+		}
+
+		deployment.Spec.Template = *template
+		_, err = deployments.Update(ctx, deployment, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		loggerFor(ctx).Error("Failed to roll back deployment", "error", err, "name", name, "namespace", namespace, "revision", revision)
+		return domain.DeploymentRevision{}, err
+	}
+
+	loggerFor(ctx).Info("Rolled back deployment", "name", name, "namespace", namespace, "revision", target.Revision)
+	return target, nil
+}
+
+// ownedReplicaSets returns the ReplicaSets controlled by the deployment, newest revision first
+func (c *kubeClient) ownedReplicaSets(ctx context.Context, deployment *appsv1.Deployment) ([]*appsv1.ReplicaSet, error) {
+	// Narrow the list to the deployment's pods; ownership is checked below
+	listOptions := metav1.ListOptions{}
+	if deployment.Spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector on deployment %s/%s: %w", deployment.Namespace, deployment.Name, err)
+		}
+		listOptions.LabelSelector = selector.String()
+	}
+
+	list, err := c.clientset.AppsV1().ReplicaSets(deployment.Namespace).List(ctx, listOptions)
+	if err != nil {
+		loggerFor(ctx).Error("Failed to list replica sets", "error", err, "namespace", deployment.Namespace)
+		return nil, err
+	}
+
+	replicaSets := make([]*appsv1.ReplicaSet, 0, len(list.Items))
+	for i := range list.Items {
+		if metav1.IsControlledBy(&list.Items[i], deployment) {
+			replicaSets = append(replicaSets, &list.Items[i])
+		}
+	}
+
+	sort.SliceStable(replicaSets, func(i, j int) bool {
+		ri, rj := replicaSetRevision(replicaSets[i]), replicaSetRevision(replicaSets[j])
+		if ri != rj {
+			return ri > rj
+		}
+		return replicaSets[j].CreationTimestamp.Before(&replicaSets[i].CreationTimestamp)
+	})
+	return replicaSets, nil
+}
+
+// findRevision picks the ReplicaSet with the revision from replica sets sorted newest first.
+// Revision 0 picks the one before the newest.
+func findRevision(replicaSets []*appsv1.ReplicaSet, revision int64) (*appsv1.ReplicaSet, error) {
+	if revision == 0 {
+		if len(replicaSets) < 2 {
+			return nil, fmt.Errorf("%w: no previous revision", ErrRevisionNotFound)
+		}
+		return replicaSets[1], nil
+	}
+
+	for _, rs := range replicaSets {
+		if replicaSetRevision(rs) == revision {
+			return rs, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: revision %d", ErrRevisionNotFound, revision)
+}
+
+// replicaSetRevision returns the revision annotation of the ReplicaSet, 0 if it is missing or invalid
+func replicaSetRevision(rs *appsv1.ReplicaSet) int64 {
+	revision, _ := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+	return revision
+}
+
+// toDomainRevision converts a ReplicaSet to a deployment revision. ReplicaSets without a valid
+// revision annotation get revision 0.
+func toDomainRevision(rs *appsv1.ReplicaSet) domain.DeploymentRevision {
+	images := make([]string, 0, len(rs.Spec.Template.Spec.Containers))
+	for _, container := range rs.Spec.Template.Spec.Containers {
+		images = append(images, container.Image)
+	}
+
+	replicas := int32(0)
+	if rs.Spec.Replicas != nil {
+		replicas = *rs.Spec.Replicas
+	}
+
+	return domain.DeploymentRevision{
+		Revision:    replicaSetRevision(rs),
+		ReplicaSet:  rs.Name,
+		ChangeCause: rs.Annotations[changeCauseAnnotation],
+		Images:      images,
+		Replicas:    replicas,
+		CreatedAt:   rs.CreationTimestamp.UTC(),
+	}
+}
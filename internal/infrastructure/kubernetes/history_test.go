@@ -0,0 +1,137 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestListDeploymentHistory(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: types.UID("web-uid")},
+		Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+	}
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	replicaSet := func(name, revision string, owner *appsv1.Deployment, age time.Duration) *appsv1.ReplicaSet {
+		rs := &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				Labels:            map[string]string{"app": "web"},
+				Annotations:       map[string]string{revisionAnnotation: revision, changeCauseAnnotation: "deploy " + name},
+				CreationTimestamp: metav1.NewTime(created.Add(-age)),
+			},
+		}
+		if owner != nil {
+			rs.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(owner, appsv1.SchemeGroupVersion.WithKind("Deployment"))}
+		}
+		return rs
+	}
+	other := deployment.DeepCopy()
+	other.UID = types.UID("other-uid")
+
+	client := newTestClient(
+		deployment,
+		replicaSet("web-1", "1", deployment, 2*time.Hour),
+		replicaSet("web-10", "10", deployment, 0),
+		replicaSet("web-2", "2", deployment, time.Hour),
+		replicaSet("adopted-elsewhere", "7", other, 0),
+		replicaSet("orphan", "8", nil, 0),
+	)
+
+	revisions, err := client.ListDeploymentHistory(context.Background(), "default", "web")
+	if err != nil {
+		t.Fatalf("ListDeploymentHistory failed: %v", err)
+	}
+
+	want := []string{"web-10", "web-2", "web-1"}
+	if len(revisions) != len(want) {
+		t.Fatalf("expected %d revisions, got %+v", len(want), revisions)
+	}
+	for i, name := range want {
+		if revisions[i].ReplicaSet != name {
+			t.Errorf("revision %d: expected %s, got %s", i, name, revisions[i].ReplicaSet)
+		}
+	}
+	if revisions[0].Revision != 10 || revisions[0].ChangeCause != "deploy web-10" || !revisions[0].CreatedAt.Equal(created) {
+		t.Errorf("unexpected newest revision %+v", revisions[0])
+	}
+
+	if _, err := client.ListDeploymentHistory(context.Background(), "default", "missing"); !apierrors.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestRollbackDeployment(t *testing.T) {
+	template := func(image, hash string) corev1.PodTemplateSpec {
+		labels := map[string]string{"app": "web"}
+		if hash != "" {
+			labels[appsv1.DefaultDeploymentUniqueLabelKey] = hash
+		}
+		return corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: labels},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: image}}},
+		}
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: types.UID("web-uid")},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Template: template("web:3", ""),
+		},
+	}
+	replicaSet := func(revision, image string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "web-" + revision,
+				Namespace:       "default",
+				Labels:          map[string]string{"app": "web"},
+				Annotations:     map[string]string{revisionAnnotation: revision},
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(deployment, appsv1.SchemeGroupVersion.WithKind("Deployment"))},
+			},
+			Spec: appsv1.ReplicaSetSpec{Template: template(image, "hash-"+revision)},
+		}
+	}
+	client := newTestClient(deployment, replicaSet("1", "web:1"), replicaSet("2", "web:2"), replicaSet("3", "web:3"))
+	ctx := context.Background()
+
+	image := func() string {
+		t.Helper()
+		current, err := client.clientset.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get deployment: %v", err)
+		}
+		if _, ok := current.Spec.Template.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; ok {
+			t.Error("expected the pod-template-hash label to be removed")
+		}
+		return current.Spec.Template.Spec.Containers[0].Image
+	}
+
+	target, err := client.RollbackDeployment(ctx, "default", "web", 1)
+	if err != nil {
+		t.Fatalf("RollbackDeployment failed: %v", err)
+	}
+	if target.Revision != 1 || image() != "web:1" {
+		t.Errorf("expected a rollback to revision 1 with web:1, got revision %d with %s", target.Revision, image())
+	}
+
+	// Without a revision the deployment goes back to the one before the newest
+	if target, err = client.RollbackDeployment(ctx, "default", "web", 0); err != nil {
+		t.Fatalf("RollbackDeployment failed: %v", err)
+	}
+	if target.Revision != 2 || image() != "web:2" {
+		t.Errorf("expected a rollback to revision 2 with web:2, got revision %d with %s", target.Revision, image())
+	}
+
+	if _, err := client.RollbackDeployment(ctx, "default", "web", 9); !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("expected ErrRevisionNotFound, got %v", err)
+	}
+}
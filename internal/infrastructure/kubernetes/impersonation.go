@@ -0,0 +1,66 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// SetImpersonation sets the user and groups that API calls are made as.
+// It must be called before Connect to take effect.
+func (c *kubeClient) SetImpersonation(user string, groups []string) {
+	c.impersonateUser = user
+	c.impersonateGroups = groups
+}
+
+// applyImpersonation verifies that the base credentials may impersonate the configured
+// identity and then sets the impersonation headers on the rest config
+func (c *kubeClient) applyImpersonation(ctx context.Context, config *rest.Config) error {
+	if c.impersonateUser == "" {
+		return fmt.Errorf("impersonating groups requires an impersonated user")
+	}
+
+	baseClientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	if err := checkImpersonationAccess(ctx, baseClientset, c.impersonateUser, c.impersonateGroups); err != nil {
+		return err
+	}
+
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: c.impersonateUser,
+		Groups:   c.impersonateGroups,
+	}
+
+	slog.Info("Impersonating user", "user", c.impersonateUser, "groups", c.impersonateGroups)
+	return nil
+}
+
+// checkImpersonationAccess uses self subject access reviews to confirm the current
+// credentials have impersonate permission on the user and every group
+func checkImpersonationAccess(ctx context.Context, clientset kubernetes.Interface, user string, groups []string) error {
+	checks := []*authorizationv1.ResourceAttributes{
+		{Verb: "impersonate", Resource: "users", Name: user},
+	}
+	for _, group := range groups {
+		checks = append(checks, &authorizationv1.ResourceAttributes{Verb: "impersonate", Resource: "groups", Name: group})
+	}
+
+	for _, attributes := range checks {
+		allowed, err := reviewAccess(ctx, clientset, attributes)
+		if err != nil {
+			return fmt.Errorf("failed to check impersonation permission: %w", err)
+		}
+		if !allowed {
+			return fmt.Errorf("credentials are not allowed to impersonate %s %q", attributes.Resource, attributes.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCheckImpersonationAccess(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		review.Status.Allowed = attributes.Verb == "impersonate" && attributes.Name != "system:masters"
+		return true, review, nil
+	})
+
+	ctx := context.Background()
+
+	if err := checkImpersonationAccess(ctx, clientset, "jane", []string{"developers"}); err != nil {
+		t.Errorf("expected impersonation to be allowed, got %v", err)
+	}
+
+	if err := checkImpersonationAccess(ctx, clientset, "jane", []string{"developers", "system:masters"}); err == nil {
+		t.Error("expected an error when a group cannot be impersonated")
+	}
+}
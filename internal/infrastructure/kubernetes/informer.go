@@ -2,81 +2,269 @@ package kubernetes
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
-	appsv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
 	"k8s-controller/internal/domain"
 )
 
+// defaultClusterScopeThreshold is the number of watched namespaces above which a single
+// cluster-scoped informer factory is used
+const defaultClusterScopeThreshold = 10
+
 // ResourceEventHandler is an interface for handling resource events
 type ResourceEventHandler interface {
 	HandleEvent(ctx context.Context, event domain.ResourceEvent) error
 }
 
-// startInformers initializes and starts informers for the given resources
-func (c *kubeClient) startInformers(ctx context.Context, namespaces []string, resources []string, handler ResourceEventHandler) error {
+// newInformerFactory creates an informer factory for the namespace using the
+// default resync period, with overrides for resources that have their own entry
+func (c *kubeClient) newInformerFactory(namespace string) informers.SharedInformerFactory {
+	options := []informers.SharedInformerOption{informers.WithNamespace(namespace)}
+
+	customResync := make(map[metav1.Object]time.Duration)
+	for resource, period := range c.resyncPeriods {
+		rt, ok := lookupResourceType(resource)
+		if !ok {
+			slog.Warn("Ignoring resync period for unsupported resource type", "resource", resource)
+			continue
+		}
+		customResync[rt.object] = period
+	}
+	if len(customResync) > 0 {
+		options = append(options, informers.WithCustomResyncConfig(customResync))
+	}
+
+	// Filter on the API server so objects outside the selector are never cached
+	if selector := c.watchSelector; selector != "" {
+		options = append(options, informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = selector
+		}))
+	}
+
+	return informers.NewSharedInformerFactoryWithOptions(c.clientset, c.resyncPeriod, options...)
+}
+
+// restrictToWatchSelector adds the watch selector and the resource's own selector to a label
+// selector, so lists that bypass the cache return the same objects as the filtered informers
+func (c *kubeClient) restrictToWatchSelector(resource, selector string) string {
+	return joinSelectors(selector, c.watchSelector, c.resourceSelectors[resource])
+}
+
+// joinSelectors combines label selectors into one that requires all of them, skipping empty ones
+func joinSelectors(selectors ...string) string {
+	var parts []string
+	for _, selector := range selectors {
+		if selector != "" {
+			parts = append(parts, selector)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// startInformers adds event handlers to the shared informers for the given resources
+// and starts them. The same factories serve both event handling and listing.
+func (c *kubeClient) startInformers(ctx context.Context, namespaces []string, resources []string) error {
 	slog.Info("Starting informers", "namespaces", namespaces, "resources", resources)
 
-	// Create a factory for each namespace
 	for _, namespace := range namespaces {
-		factory := informers.NewSharedInformerFactoryWithOptions(
-			c.clientset,
-			30*time.Second, // resync period
-			informers.WithNamespace(namespace),
-		)
+		factory := c.getOrCreateInformerFactory(namespace)
 
-		// Set up informers for each resource type
+		// Set up informers for each resource type, each is started when it is created
 		for _, resource := range resources {
-			if err := c.setupInformer(ctx, factory, resource, namespace, handler); err != nil {
+			if err := c.setupInformer(ctx, factory, resource, namespace); err != nil {
 				return err
 			}
 		}
-
-		// Start the informer factory
-		factory.Start(ctx.Done())
 	}
 
 	return nil
 }
 
+// clusterScoped reports whether the client shares one cluster-scoped informer factory between
+// all watched namespaces instead of creating one per namespace. This keeps one watch connection
+// per resource type when many namespaces are watched. The decision is fixed once the first
+// factory exists.
+func (c *kubeClient) clusterScoped() bool {
+	c.namespacesMu.RLock()
+	defer c.namespacesMu.RUnlock()
+	if c.scopeFixed {
+		return c.clusterScope
+	}
+	return c.clusterScopeLocked()
+}
+
+// fixInformerScope records the current scope decision, before the first factory is created
+func (c *kubeClient) fixInformerScope() {
+	c.namespacesMu.Lock()
+	defer c.namespacesMu.Unlock()
+	if !c.scopeFixed {
+		c.clusterScope = c.clusterScopeLocked()
+		c.scopeFixed = true
+	}
+}
+
+// clusterScopeLocked computes the scope from the namespaces; namespacesMu must be held
+func (c *kubeClient) clusterScopeLocked() bool {
+	return !c.perNamespaceFactories && c.clusterScopeThreshold > 0 && len(c.namespaces) > c.clusterScopeThreshold
+}
+
+// factoryNamespace returns the key of the informer factory serving the namespace
+func (c *kubeClient) factoryNamespace(namespace string) string {
+	if c.clusterScoped() {
+		return metav1.NamespaceAll
+	}
+	return namespace
+}
+
+// UnwrapTombstone returns the last known object inside a DeletedFinalStateUnknown tombstone,
+// or obj itself if it is not a tombstone. Every path that converts store or event objects
+// should unwrap first, since deletions missed by a watch are delivered as tombstones.
+func UnwrapTombstone(obj interface{}) interface{} {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		return tombstone.Obj
+	}
+	if tombstone, ok := obj.(*cache.DeletedFinalStateUnknown); ok && tombstone != nil {
+		return tombstone.Obj
+	}
+	return obj
+}
+
+// isWatchedNamespace reports whether the object, or the object inside a tombstone, is in a watched namespace
+func (c *kubeClient) isWatchedNamespace(obj interface{}) bool {
+	metaObj, ok := UnwrapTombstone(obj).(metav1.Object)
+	if !ok {
+		return false
+	}
+	return slices.Contains(c.watchedNamespaces(), metaObj.GetNamespace())
+}
+
+// getOrCreateInformerFactory returns the shared informer factory for the namespace, creating it if needed
+func (c *kubeClient) getOrCreateInformerFactory(namespace string) informers.SharedInformerFactory {
+	c.fixInformerScope()
+	namespace = c.factoryNamespace(namespace)
+
+	c.factoriesMu.Lock()
+	defer c.factoriesMu.Unlock()
+
+	if factory, ok := c.informerFactories[namespace]; ok {
+		return factory
+	}
+
+	factory := c.newInformerFactory(namespace)
+	c.informerFactories[namespace] = factory
+	return factory
+}
+
+// markInformerHandled records that event handlers were added for the resource in the namespace.
+// It returns false if handlers were already added, so they are never registered twice.
+func (c *kubeClient) markInformerHandled(namespace, resource string) bool {
+	c.factoriesMu.Lock()
+	defer c.factoriesMu.Unlock()
+
+	key := c.factoryNamespace(namespace) + "/" + resource
+	if c.handledInformers[key] {
+		return false
+	}
+	c.handledInformers[key] = true
+	return true
+}
+
+// createInformer returns the informer for the resource type from the factory, records
+// that it exists, so the cache is only read for informers that run, and starts it
+func (c *kubeClient) createInformer(factory informers.SharedInformerFactory, namespace string, rt resourceType) cache.SharedIndexInformer {
+	// The factory keeps one informer per type, so creating the filtered one first makes every
+	// later lookup of the type return it
+	if resourceSelector := c.resourceSelectors[rt.Resource]; resourceSelector != "" {
+		selector := joinSelectors(c.watchSelector, resourceSelector)
+		factory.InformerFor(rt.object.(runtime.Object), func(clientset kubernetes.Interface, resync time.Duration) cache.SharedIndexInformer {
+			return rt.filteredInformer(clientset, c.factoryNamespace(namespace), resync, namespaceIndexers(), func(opts *metav1.ListOptions) {
+				opts.LabelSelector = selector
+			})
+		})
+	}
+
+	informer := rt.informer(factory)
+	key := c.factoryNamespace(namespace) + "/" + rt.Resource
+
+	c.factoriesMu.Lock()
+	c.createdInformers[key] = true
+
+	// Label indexes can be added after the informer started, the existing items are indexed then
+	if rt.Resource == "deployments" && len(c.indexLabels) > 0 {
+		c.addLabelIndexers(informer)
+	}
+	c.factoriesMu.Unlock()
+
+	c.startInformer(key, informer)
+	return informer
+}
+
+// syncedInformer returns the informer for the resource type in the namespace only if it
+// was created by the client and its cache has synced. It never creates informers.
+func (c *kubeClient) syncedInformer(namespace string, rt resourceType) (cache.SharedIndexInformer, bool) {
+	factory, ok := c.getInformerFactory(namespace)
+	if !ok {
+		return nil, false
+	}
+
+	c.factoriesMu.RLock()
+	created := c.createdInformers[c.factoryNamespace(namespace)+"/"+rt.Resource]
+	c.factoriesMu.RUnlock()
+	if !created {
+		return nil, false
+	}
+
+	informer := rt.informer(factory)
+	if !informer.HasSynced() {
+		return nil, false
+	}
+	return informer, true
+}
+
 // setupInformer creates an informer for a specific resource type
-func (c *kubeClient) setupInformer(ctx context.Context, factory informers.SharedInformerFactory, resource string, namespace string, handler ResourceEventHandler) error {
-	var informer cache.SharedIndexInformer
-
-	// Configure the appropriate informer based on resource type
-	switch resource {
-	case "pods", "pod":
-		informer = factory.Core().V1().Pods().Informer()
-	case "services", "service":
-		informer = factory.Core().V1().Services().Informer()
-	case "deployments", "deployment":
-		informer = factory.Apps().V1().Deployments().Informer()
-	case "configmaps", "configmap":
-		informer = factory.Core().V1().ConfigMaps().Informer()
-	default:
+func (c *kubeClient) setupInformer(ctx context.Context, factory informers.SharedInformerFactory, resource string, namespace string) error {
+	rt, ok := lookupResourceType(resource)
+	if !ok {
 		slog.Warn("Unsupported resource type", "resource", resource)
 		return nil
 	}
+	informer := c.createInformer(factory, namespace, rt)
 
-	// Add event handlers
-	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+	if !c.markInformerHandled(namespace, rt.Resource) {
+		slog.Debug("Informer already has event handlers", "resource", resource, "namespace", namespace)
+		return nil
+	}
+
+	var handler cache.ResourceEventHandler = cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
-			c.handleAddEvent(ctx, obj, handler)
+			c.handleAddEvent(ctx, obj)
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
-			c.handleUpdateEvent(ctx, oldObj, newObj, handler)
+			c.handleUpdateEvent(ctx, oldObj, newObj)
 		},
 		DeleteFunc: func(obj interface{}) {
-			c.handleDeleteEvent(ctx, obj, handler)
+			c.handleDeleteEvent(ctx, obj)
 		},
-	})
+	}
+
+	// A cluster-scoped informer sees every namespace, so drop events from namespaces that are not watched
+	if c.clusterScoped() {
+		handler = cache.FilteringResourceEventHandler{FilterFunc: c.isWatchedNamespace, Handler: handler}
+	}
+
+	// Add event handlers
+	_, err := informer.AddEventHandler(handler)
 
 	if err != nil {
 		slog.Error("Failed to add event handler", "resource", resource, "namespace", namespace, "error", err)
@@ -88,7 +276,7 @@ func (c *kubeClient) setupInformer(ctx context.Context, factory informers.Shared
 }
 
 // handleAddEvent processes resource creation events
-func (c *kubeClient) handleAddEvent(ctx context.Context, obj interface{}, handler ResourceEventHandler) {
+func (c *kubeClient) handleAddEvent(ctx context.Context, obj interface{}) {
 	metaObj, ok := obj.(metav1.Object)
 	if !ok {
 		slog.Error("Failed to convert object to metav1.Object")
@@ -103,16 +291,12 @@ func (c *kubeClient) handleAddEvent(ctx context.Context, obj interface{}, handle
 	}
 
 	// Process the event
-	if err := handler.HandleEvent(ctx, event); err != nil {
-		slog.Error("Failed to handle add event",
-			"name", metaObj.GetName(),
-			"namespace", metaObj.GetNamespace(),
-			"error", err)
-	}
+	slog.Debug("Queueing resource event", "type", event.Type, "name", metaObj.GetName(), "namespace", metaObj.GetNamespace())
+	c.dispatchEvent(event)
 }
 
 // handleUpdateEvent processes resource update events
-func (c *kubeClient) handleUpdateEvent(ctx context.Context, oldObj, newObj interface{}, handler ResourceEventHandler) {
+func (c *kubeClient) handleUpdateEvent(ctx context.Context, oldObj, newObj interface{}) {
 	metaObj, ok := newObj.(metav1.Object)
 	if !ok {
 		slog.Error("Failed to convert object to metav1.Object")
@@ -127,29 +311,17 @@ func (c *kubeClient) handleUpdateEvent(ctx context.Context, oldObj, newObj inter
 	}
 
 	// Process the event
-	if err := handler.HandleEvent(ctx, event); err != nil {
-		slog.Error("Failed to handle update event",
-			"name", metaObj.GetName(),
-			"namespace", metaObj.GetNamespace(),
-			"error", err)
-	}
+	slog.Debug("Queueing resource event", "type", event.Type, "name", metaObj.GetName(), "namespace", metaObj.GetNamespace())
+	c.dispatchEvent(event)
 }
 
 // handleDeleteEvent processes resource deletion events
-func (c *kubeClient) handleDeleteEvent(ctx context.Context, obj interface{}, handler ResourceEventHandler) {
-	metaObj, ok := obj.(metav1.Object)
+func (c *kubeClient) handleDeleteEvent(ctx context.Context, obj interface{}) {
+	// Deleted objects may arrive as tombstones
+	metaObj, ok := UnwrapTombstone(obj).(metav1.Object)
 	if !ok {
-		// Handle deleted objects that might be tombstones
-		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
-		if !ok {
-			slog.Error("Failed to convert object to metav1.Object or tombstone")
-			return
-		}
-		metaObj, ok = tombstone.Obj.(metav1.Object)
-		if !ok {
-			slog.Error("Failed to convert tombstone to metav1.Object")
-			return
-		}
+		slog.Error("Failed to convert object or tombstone to metav1.Object")
+		return
 	}
 
 	// Convert to domain model
@@ -160,20 +332,50 @@ func (c *kubeClient) handleDeleteEvent(ctx context.Context, obj interface{}, han
 	}
 
 	// Process the event
-	if err := handler.HandleEvent(ctx, event); err != nil {
-		slog.Error("Failed to handle delete event",
-			"name", metaObj.GetName(),
-			"namespace", metaObj.GetNamespace(),
-			"error", err)
+	slog.Debug("Queueing resource event", "type", event.Type, "name", metaObj.GetName(), "namespace", metaObj.GetNamespace())
+	c.dispatchEvent(event)
+}
+
+// dispatchEvent queues the event for delivery to every registered handler.
+// Each handler receives and retries the event independently, so a failing
+// handler does not affect the others.
+func (c *kubeClient) dispatchEvent(event domain.ResourceEvent) {
+	for _, handler := range c.getEventHandlers() {
+		if !c.reserveEventBuffer(event) {
+			continue
+		}
+		c.eventQueue.Add(&queuedEvent{event: event, handler: handler})
+	}
+}
+
+// ReplayEvent delivers the event to every registered handler right away, as if an informer had
+// seen it, and returns their errors. Unlike informer events it is neither queued nor retried,
+// so callers see the handler error. It is meant for reproducing handler bugs.
+func (c *kubeClient) ReplayEvent(ctx context.Context, event domain.ResourceEvent) error {
+	handlers := c.getEventHandlers()
+	if len(handlers) == 0 {
+		return fmt.Errorf("no event handler registered")
+	}
+
+	loggerFor(ctx).Info("Replaying resource event",
+		"type", event.Type,
+		"kind", event.Resource.Kind,
+		"name", event.Resource.Name,
+		"namespace", event.Resource.Namespace)
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler.HandleEvent(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
 	}
+	return errors.Join(errs...)
 }
 
 // convertToDomainResource converts a Kubernetes object to a domain resource
 func (c *kubeClient) convertToDomainResource(obj interface{}) domain.Resource {
 	// Handle tombstones from deletion events
-	if tombstone, isTombstone := obj.(cache.DeletedFinalStateUnknown); isTombstone {
-		obj = tombstone.Obj
-	}
+	obj = UnwrapTombstone(obj)
 
 	// Get metadata from the object
 	metaObj, ok := obj.(metav1.Object)
@@ -189,33 +391,36 @@ func (c *kubeClient) convertToDomainResource(obj interface{}) domain.Resource {
 		if gvk.Kind != "" {
 			kind = gvk.Kind
 		} else {
-			// Use type reflection as a fallback for kind detection
-			kind = c.getKindFromResourceType(obj)
+			// Objects from informers have no type meta, so look the kind up by Go type
+			if rt, ok := resourceTypeForObject(obj); ok {
+				kind = rt.Kind
+			}
 		}
 	}
 
 	// Create the domain resource
 	return domain.Resource{
-		Kind:      kind,
-		Name:      metaObj.GetName(),
-		Namespace: metaObj.GetNamespace(),
-		Labels:    metaObj.GetLabels(),
-	}
-}
-
-// getKindFromResourceType attempts to determine the kind based on the type of the object
-func (c *kubeClient) getKindFromResourceType(obj interface{}) string {
-	// Simple resource type detection based on specific object types
-	switch obj.(type) {
-	case *corev1.Pod:
-		return "Pod"
-	case *corev1.Service:
-		return "Service"
-	case *appsv1.Deployment:
-		return "Deployment"
-	case *corev1.ConfigMap:
-		return "ConfigMap"
-	default:
-		return "Unknown"
+		Kind:            kind,
+		Name:            metaObj.GetName(),
+		Namespace:       metaObj.GetNamespace(),
+		Labels:          metaObj.GetLabels(),
+		OwnerReferences: toDomainOwnerRefs(metaObj.GetOwnerReferences()),
+	}
+}
+
+// toDomainOwnerRefs converts owner references to the domain model, nil if there are none
+func toDomainOwnerRefs(refs []metav1.OwnerReference) []domain.OwnerRef {
+	if len(refs) == 0 {
+		return nil
+	}
+	owners := make([]domain.OwnerRef, 0, len(refs))
+	for _, ref := range refs {
+		owners = append(owners, domain.OwnerRef{
+			Kind:       ref.Kind,
+			Name:       ref.Name,
+			UID:        string(ref.UID),
+			Controller: ref.Controller != nil && *ref.Controller,
+		})
 	}
+	return owners
 }
@@ -0,0 +1,120 @@
+package kubernetes
+
+import (
+	"context"
+	"log/slog"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s-controller/internal/domain"
+)
+
+// labelIndexName returns the name of the informer index for the label key
+func labelIndexName(key string) string {
+	return "label:" + key
+}
+
+// labelIndexKey returns the index key for a label value in a namespace.
+// An empty namespace matches the value in every namespace.
+func labelIndexKey(namespace, value string) string {
+	return namespace + "/" + value
+}
+
+// labelIndexFunc indexes objects by the value of the label key, both per namespace and across
+// all namespaces. Objects without the label are not indexed.
+func labelIndexFunc(key string) cache.IndexFunc {
+	return func(obj interface{}) ([]string, error) {
+		metaObj, ok := UnwrapTombstone(obj).(metav1.Object)
+		if !ok {
+			return nil, nil
+		}
+		value, ok := metaObj.GetLabels()[key]
+		if !ok {
+			return nil, nil
+		}
+		return []string{labelIndexKey(metaObj.GetNamespace(), value), labelIndexKey(metav1.NamespaceAll, value)}, nil
+	}
+}
+
+// addLabelIndexers registers an index for each configured label on the informer.
+// Indexes that already exist are left alone.
+func (c *kubeClient) addLabelIndexers(informer cache.SharedIndexInformer) {
+	existing := informer.GetIndexer().GetIndexers()
+	indexers := cache.Indexers{}
+	for _, key := range c.indexLabels {
+		if _, ok := existing[labelIndexName(key)]; !ok {
+			indexers[labelIndexName(key)] = labelIndexFunc(key)
+		}
+	}
+	if len(indexers) == 0 {
+		return
+	}
+
+	if err := informer.AddIndexers(indexers); err != nil {
+		slog.Warn("Failed to add label indexers", "labels", c.indexLabels, "error", err)
+	}
+}
+
+// indexedLabel returns the label key and value if the selector is a single equality
+// requirement on a label that has an index
+func (c *kubeClient) indexedLabel(selector labels.Selector) (string, string, bool) {
+	requirements, selectable := selector.Requirements()
+	if !selectable || len(requirements) != 1 {
+		return "", "", false
+	}
+
+	requirement := requirements[0]
+	switch requirement.Operator() {
+	case selection.Equals, selection.DoubleEquals:
+	default:
+		return "", "", false
+	}
+
+	for _, key := range c.indexLabels {
+		if key == requirement.Key() {
+			value, _ := requirement.Values().PopAny()
+			return key, value, true
+		}
+	}
+	return "", "", false
+}
+
+// listObjectsByLabelIndex lists objects whose label key equals value using the label index of the
+// synced informer. It reports false if there is no synced informer with that index.
+func (c *kubeClient) listObjectsByLabelIndex(rt resourceType, namespace, key, value string) ([]runtime.Object, bool, error) {
+	informer, ok := c.syncedInformer(namespace, rt)
+	if !ok {
+		return nil, false, nil
+	}
+	if _, ok := informer.GetIndexer().GetIndexers()[labelIndexName(key)]; !ok {
+		return nil, false, nil
+	}
+
+	items, err := informer.GetIndexer().ByIndex(labelIndexName(key), labelIndexKey(namespace, value))
+	if err != nil {
+		return nil, true, err
+	}
+
+	objects := make([]runtime.Object, 0, len(items))
+	for _, item := range items {
+		if runtimeObj, ok := UnwrapTombstone(item).(runtime.Object); ok {
+			objects = append(objects, runtimeObj)
+		}
+	}
+	return objects, true, nil
+}
+
+// ListDeploymentsByLabel retrieves deployments in the namespace whose label key equals value.
+// It reads the label index when the key is listed in the index labels, otherwise it lists
+// from the cache or the API like ListDeploymentsBySelector.
+func (c *kubeClient) ListDeploymentsByLabel(ctx context.Context, namespace, key, value string) ([]domain.Deployment, error) {
+	selector, err := labels.ValidatedSelectorFromSet(labels.Set{key: value})
+	if err != nil {
+		return nil, err
+	}
+	return c.ListDeploymentsBySelector(ctx, namespace, selector.String())
+}
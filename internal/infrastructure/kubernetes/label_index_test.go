@@ -0,0 +1,67 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestListDeploymentsByLabelUsesIndex(t *testing.T) {
+	client := newTestClient(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"team": "web"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", Labels: map[string]string{"team": "backend"}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "other", Labels: map[string]string{"team": "web"}}},
+	)
+	client.SetIndexLabels([]string{"team"})
+	ctx := context.Background()
+
+	if err := client.InitializeInformers(ctx, []string{"default"}); err != nil {
+		t.Fatalf("InitializeInformers failed: %v", err)
+	}
+	defer client.Stop()
+
+	deployments, _ := lookupResourceType("deployments")
+	objects, indexed, err := client.listObjectsByLabelIndex(deployments, "default", "team", "web")
+	if err != nil || !indexed {
+		t.Fatalf("expected the team label to be indexed, got indexed=%v err=%v", indexed, err)
+	}
+	if len(objects) != 1 {
+		t.Errorf("expected 1 indexed deployment in default, got %d", len(objects))
+	}
+
+	result, err := client.ListDeploymentsByLabel(ctx, "default", "team", "backend")
+	if err != nil {
+		t.Fatalf("ListDeploymentsByLabel failed: %v", err)
+	}
+	if len(result) != 1 || result[0].Name != "api" {
+		t.Errorf("expected only the api deployment, got %+v", result)
+	}
+
+	// Selectors that are not a single equality on an indexed label still work
+	result, err = client.ListDeploymentsBySelector(ctx, "default", "team in (web,backend)")
+	if err != nil {
+		t.Fatalf("ListDeploymentsBySelector failed: %v", err)
+	}
+	if len(result) != 2 {
+		t.Errorf("expected 2 deployments, got %d", len(result))
+	}
+}
+
+func TestLabelIndexFuncIndexesAcrossNamespaces(t *testing.T) {
+	keys, err := labelIndexFunc("team")(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"team": "web"}},
+	})
+	if err != nil {
+		t.Fatalf("index func failed: %v", err)
+	}
+	if len(keys) != 2 || keys[0] != "default/web" || keys[1] != "/web" {
+		t.Errorf("unexpected index keys %v", keys)
+	}
+
+	keys, _ = labelIndexFunc("team")(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "unlabelled"}})
+	if len(keys) != 0 {
+		t.Errorf("expected unlabelled objects not to be indexed, got %v", keys)
+	}
+}
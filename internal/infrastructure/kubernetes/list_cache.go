@@ -0,0 +1,124 @@
+package kubernetes
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// listCacheBypassKey is the context key marking requests that skip the list cache
+type listCacheBypassKey struct{}
+
+// WithoutListCache returns a context whose direct API lists skip the list cache. The fresh
+// result still replaces the cached one.
+func WithoutListCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, listCacheBypassKey{}, true)
+}
+
+// listCacheBypassed reports whether ctx was returned by WithoutListCache
+func listCacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(listCacheBypassKey{}).(bool)
+	return bypass
+}
+
+// listCacheKey identifies a direct API list
+type listCacheKey struct {
+	resource  string
+	namespace string
+	selector  string
+}
+
+// listCacheEntry is a cached list result and the time it expires
+type listCacheEntry struct {
+	objects []runtime.Object
+	expires time.Time
+}
+
+// listCache keeps the results of direct API lists, made when no synced informer exists, for a
+// short time so repeated requests don't each hit the API. Writes made through the client drop
+// the affected entries; changes made by others are seen once the entries expire. Cached objects
+// are shared and must not be modified, like objects from an informer store.
+type listCache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[listCacheKey]listCacheEntry
+	// generation is incremented by every invalidation, so a list that started before one is not cached
+	generation uint64
+}
+
+// newListCache creates a cache whose entries expire after ttl
+func newListCache(ttl time.Duration) *listCache {
+	return &listCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[listCacheKey]listCacheEntry),
+	}
+}
+
+// get returns the cached objects of the list, if they have not expired
+func (lc *listCache) get(key listCacheKey) ([]runtime.Object, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	entry, ok := lc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !lc.now().Before(entry.expires) {
+		delete(lc.entries, key)
+		return nil, false
+	}
+	return entry.objects, true
+}
+
+// currentGeneration returns the generation to pass to put for a list that starts now
+func (lc *listCache) currentGeneration() uint64 {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	return lc.generation
+}
+
+// put caches the objects of a list started at generation and removes expired entries. The
+// objects are not cached if the cache was invalidated since, they may predate the write.
+func (lc *listCache) put(key listCacheKey, generation uint64, objects []runtime.Object) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if generation != lc.generation {
+		return
+	}
+
+	now := lc.now()
+	for k, entry := range lc.entries {
+		if !now.Before(entry.expires) {
+			delete(lc.entries, k)
+		}
+	}
+	lc.entries[key] = listCacheEntry{objects: objects, expires: now.Add(lc.ttl)}
+}
+
+// invalidate drops the cached lists of the resource that may include objects of the namespace,
+// which are its lists of the namespace and of all namespaces
+func (lc *listCache) invalidate(resource, namespace string) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	lc.generation++
+	for key := range lc.entries {
+		if key.resource == resource && (key.namespace == namespace || key.namespace == metav1.NamespaceAll) {
+			delete(lc.entries, key)
+		}
+	}
+}
+
+// invalidateListCache drops the cached lists affected by a write to the resource in the
+// namespace, if the list cache is enabled
+func (c *kubeClient) invalidateListCache(resource, namespace string) {
+	if c.listCache != nil {
+		c.listCache.invalidate(resource, namespace)
+	}
+}
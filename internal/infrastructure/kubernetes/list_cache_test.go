@@ -0,0 +1,113 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s-controller/internal/domain"
+)
+
+func TestListCacheReusesAPIListsWithinTTL(t *testing.T) {
+	client := newTestClient(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}})
+	client.SetListCacheTTL(time.Minute)
+	now := time.Now()
+	client.listCache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	apiLists := func() int {
+		count := 0
+		for _, action := range client.clientset.(*fake.Clientset).Actions() {
+			if action.GetVerb() == "list" && action.GetResource().Resource == "deployments" {
+				count++
+			}
+		}
+		return count
+	}
+	list := func(ctx context.Context, namespace string) {
+		t.Helper()
+		if _, err := client.ListDeployments(ctx, namespace); err != nil {
+			t.Fatalf("ListDeployments failed: %v", err)
+		}
+	}
+
+	list(ctx, "default")
+	list(ctx, "default")
+	if got := apiLists(); got != 1 {
+		t.Errorf("expected the second list to be served from the cache, got %d API lists", got)
+	}
+
+	// Namespaces are cached separately
+	list(ctx, "other")
+	if got := apiLists(); got != 2 {
+		t.Errorf("expected another namespace to hit the API, got %d API lists", got)
+	}
+
+	// A bypassing request always hits the API
+	list(WithoutListCache(ctx), "default")
+	if got := apiLists(); got != 3 {
+		t.Errorf("expected a bypassing list to hit the API, got %d API lists", got)
+	}
+
+	// Expired entries are listed again
+	now = now.Add(time.Minute)
+	list(ctx, "default")
+	if got := apiLists(); got != 4 {
+		t.Errorf("expected an expired entry to be listed again, got %d API lists", got)
+	}
+}
+
+func TestListCacheIsInvalidatedByWrites(t *testing.T) {
+	client := newTestClient(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}})
+	client.SetListCacheTTL(time.Minute)
+	ctx := context.Background()
+
+	list := func(namespace string) []string {
+		t.Helper()
+		deployments, err := client.ListDeployments(ctx, namespace)
+		if err != nil {
+			t.Fatalf("ListDeployments failed: %v", err)
+		}
+		var names []string
+		for _, deployment := range deployments {
+			names = append(names, deployment.Name)
+		}
+		return names
+	}
+
+	list("default")
+	list(metav1.NamespaceAll)
+	list("other")
+
+	resource := domain.Resource{Kind: "Deployment", Name: "api", Namespace: "default", Data: map[string]interface{}{"image": "api:1"}}
+	if err := client.ApplyResource(ctx, resource); err != nil {
+		t.Fatalf("ApplyResource failed: %v", err)
+	}
+
+	if got := list("default"); len(got) != 2 {
+		t.Errorf("expected the namespace list to include the applied deployment, got %v", got)
+	}
+	if got := list(metav1.NamespaceAll); len(got) != 2 {
+		t.Errorf("expected the all-namespaces list to include the applied deployment, got %v", got)
+	}
+	if _, ok := client.listCache.get(listCacheKey{resource: "deployments", namespace: "other"}); !ok {
+		t.Error("expected lists of other namespaces to stay cached")
+	}
+}
+
+func TestListCacheSkipsListsStartedBeforeInvalidation(t *testing.T) {
+	lc := newListCache(time.Minute)
+	key := listCacheKey{resource: "deployments", namespace: "default"}
+
+	generation := lc.currentGeneration()
+	lc.invalidate("deployments", "default")
+	lc.put(key, generation, nil)
+
+	if _, ok := lc.get(key); ok {
+		t.Error("expected a list started before the invalidation not to be cached")
+	}
+}
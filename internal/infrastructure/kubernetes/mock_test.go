@@ -0,0 +1,68 @@
+package kubernetes
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultMockFixturesDecode(t *testing.T) {
+	objects, err := decodeFixtures(defaultMockFixtures)
+	if err != nil {
+		t.Fatalf("decodeFixtures failed: %v", err)
+	}
+	if len(objects) == 0 {
+		t.Fatal("expected the built-in fixtures to contain objects")
+	}
+}
+
+func TestMockClientServesFixtures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures.yaml")
+	fixtures := `# comment only document
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: demo
+spec:
+  replicas: 3
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: demo
+`
+	if err := os.WriteFile(path, []byte(fixtures), 0o600); err != nil {
+		t.Fatalf("failed to write fixtures: %v", err)
+	}
+
+	client := NewClient(WithMockFixtures(path))
+	ctx := context.Background()
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	if err := client.CheckPermissions(ctx, []string{"demo"}, []string{"deployments"}); err != nil {
+		t.Errorf("expected every permission to be granted in mock mode, got %v", err)
+	}
+
+	deployment, err := client.GetDeployment(ctx, "demo", "web")
+	if err != nil {
+		t.Fatalf("GetDeployment failed: %v", err)
+	}
+	if deployment.Replicas != 3 {
+		t.Errorf("expected 3 replicas, got %d", deployment.Replicas)
+	}
+	services, err := client.ListServices(ctx, "demo", "")
+	if err != nil || len(services) != 1 {
+		t.Errorf("ListServices = %v, %v; want one service", services, err)
+	}
+
+	missing := NewClient(WithMockFixtures(filepath.Join(t.TempDir(), "missing.yaml")))
+	if err := missing.Connect(ctx); err == nil {
+		t.Error("expected an error for a missing fixtures file")
+	}
+}
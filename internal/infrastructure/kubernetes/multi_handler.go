@@ -0,0 +1,37 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+
+	"k8s-controller/internal/domain"
+)
+
+// MultiHandler fans out resource events to a list of handlers
+type MultiHandler struct {
+	handlers []ResourceEventHandler
+}
+
+// NewMultiHandler creates a handler that calls each of the given handlers in order.
+// Nil handlers are ignored.
+func NewMultiHandler(handlers ...ResourceEventHandler) *MultiHandler {
+	m := &MultiHandler{}
+	for _, handler := range handlers {
+		if handler != nil {
+			m.handlers = append(m.handlers, handler)
+		}
+	}
+	return m
+}
+
+// HandleEvent passes the event to every wrapped handler. All handlers are called
+// even if some fail, and their errors are joined into the returned error.
+func (m *MultiHandler) HandleEvent(ctx context.Context, event domain.ResourceEvent) error {
+	var errs []error
+	for _, handler := range m.handlers {
+		if err := handler.HandleEvent(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
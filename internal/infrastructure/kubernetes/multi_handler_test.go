@@ -0,0 +1,32 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"k8s-controller/internal/domain"
+)
+
+func TestMultiHandlerContinuesAfterError(t *testing.T) {
+	first := &recordingHandler{err: errors.New("first failed")}
+	second := &recordingHandler{}
+	third := &recordingHandler{err: errors.New("third failed")}
+
+	handler := NewMultiHandler(first, nil, second, third)
+
+	err := handler.HandleEvent(context.Background(), domain.ResourceEvent{
+		Type:     domain.ResourceEventCreated,
+		Resource: domain.Resource{Kind: "Pod", Name: "test-pod", Namespace: "default"},
+	})
+
+	if first.count() != 1 || second.count() != 1 || third.count() != 1 {
+		t.Errorf("expected every handler to be called once, got %d, %d, %d", first.count(), second.count(), third.count())
+	}
+	if err == nil {
+		t.Fatal("expected an aggregated error")
+	}
+	if !errors.Is(err, first.err) || !errors.Is(err, third.err) {
+		t.Errorf("expected error to wrap both handler errors, got %v", err)
+	}
+}
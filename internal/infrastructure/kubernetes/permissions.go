@@ -0,0 +1,81 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// watchVerbs are the verbs informers need on every watched resource
+var watchVerbs = []string{"list", "watch"}
+
+// CheckPermissions verifies that the current credentials can list and watch each resource
+// in each namespace. An empty namespace checks cluster-wide access. All missing permissions
+// are reported together.
+func (c *kubeClient) CheckPermissions(ctx context.Context, namespaces, resources []string) error {
+	if c.clientset == nil {
+		return fmt.Errorf("kubernetes client not connected")
+	}
+
+	var errs []error
+	for _, namespace := range namespaces {
+		for _, resource := range resources {
+			// Namespaces are cluster-scoped and only needed for discovery, so they are not in the registry
+			group := ""
+			if rt, ok := lookupResourceType(resource); ok {
+				resource, group = rt.Resource, rt.Group
+			} else if resource != "namespaces" {
+				slog.Warn("Skipping permission check for unsupported resource type", "resource", resource)
+				continue
+			}
+
+			for _, verb := range watchVerbs {
+				allowed, err := reviewAccess(ctx, c.clientset, &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      verb,
+					Group:     group,
+					Resource:  resource,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to check permission to %s %s: %w", verb, resource, err)
+				}
+				if !allowed {
+					errs = append(errs, fmt.Errorf("missing permission to %s %s %s", verb, resource, describeNamespace(namespace)))
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	slog.Info("Permission check passed", "namespaces", namespaces, "resources", resources)
+	return nil
+}
+
+// reviewAccess asks the API server whether the current credentials may perform the action
+func reviewAccess(ctx context.Context, clientset kubernetes.Interface, attributes *authorizationv1.ResourceAttributes) (bool, error) {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: attributes},
+	}
+
+	result, err := clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return result.Status.Allowed, nil
+}
+
+// describeNamespace returns a readable scope for permission errors
+func describeNamespace(namespace string) string {
+	if namespace == metav1.NamespaceAll {
+		return "cluster-wide"
+	}
+	return "in namespace " + namespace
+}
@@ -0,0 +1,37 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCheckPermissions(t *testing.T) {
+	client := newTestClient()
+	clientset := client.clientset.(*fake.Clientset)
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		// Deny watching pods in kube-system only
+		review.Status.Allowed = !(attributes.Namespace == "kube-system" && attributes.Resource == "pods" && attributes.Verb == "watch")
+		return true, review, nil
+	})
+
+	ctx := t.Context()
+
+	if err := client.CheckPermissions(ctx, []string{"default"}, []string{"deployments", "pod"}); err != nil {
+		t.Errorf("expected permissions to be granted, got %v", err)
+	}
+
+	err := client.CheckPermissions(ctx, []string{"default", "kube-system"}, []string{"deployments", "pods"})
+	if err == nil {
+		t.Fatal("expected a missing permission error")
+	}
+	if want := "missing permission to watch pods in namespace kube-system"; !strings.Contains(err.Error(), want) {
+		t.Errorf("expected error to contain %q, got %q", want, err.Error())
+	}
+}
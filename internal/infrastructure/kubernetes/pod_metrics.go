@@ -0,0 +1,49 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s-controller/internal/domain"
+)
+
+// ErrMetricsUnavailable is returned when the metrics.k8s.io API is not served, usually
+// because metrics-server is not installed in the cluster
+var ErrMetricsUnavailable = errors.New("metrics API not available (is metrics-server installed?)")
+
+// ListPodMetrics retrieves the current CPU and memory usage of pods in the namespace
+func (c *kubeClient) ListPodMetrics(ctx context.Context, namespace string) ([]domain.PodMetrics, error) {
+	loggerFor(ctx).Debug("Listing pod metrics", "namespace", namespace)
+
+	if c.metricsClientset == nil {
+		return nil, fmt.Errorf("kubernetes client not connected")
+	}
+
+	metricsList, err := c.metricsClientset.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsServiceUnavailable(err) {
+			return nil, ErrMetricsUnavailable
+		}
+		loggerFor(ctx).Error("Failed to list pod metrics", "error", err, "namespace", namespace)
+		return nil, err
+	}
+
+	podMetrics := make([]domain.PodMetrics, 0, len(metricsList.Items))
+	for _, item := range metricsList.Items {
+		metrics := domain.PodMetrics{
+			Name:      item.Name,
+			Namespace: item.Namespace,
+		}
+		for _, container := range item.Containers {
+			metrics.CPUMilli += container.Usage.Cpu().MilliValue()
+			metrics.MemoryBytes += container.Usage.Memory().Value()
+		}
+		podMetrics = append(podMetrics, metrics)
+	}
+
+	return podMetrics, nil
+}
@@ -0,0 +1,61 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func TestListPodMetricsSumsContainerUsage(t *testing.T) {
+	// The fake tracks pod metrics under a guessed resource name, so lists are answered by a reactor
+	metricsClientset := metricsfake.NewSimpleClientset()
+	metricsClientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &metricsv1beta1.PodMetricsList{Items: []metricsv1beta1.PodMetrics{{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"},
+			Containers: []metricsv1beta1.ContainerMetrics{
+				{Name: "web", Usage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("150m"), corev1.ResourceMemory: resource.MustParse("64Mi")}},
+				{Name: "sidecar", Usage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m"), corev1.ResourceMemory: resource.MustParse("16Mi")}},
+			},
+		}}}, nil
+	})
+	client := NewClient(WithClientset(fake.NewSimpleClientset()), WithMetricsClientset(metricsClientset))
+
+	metrics, err := client.ListPodMetrics(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("ListPodMetrics failed: %v", err)
+	}
+	if len(metrics) != 1 || metrics[0].CPUMilli != 200 || metrics[0].MemoryBytes != 80*1024*1024 {
+		t.Errorf("expected one pod using 200m CPU and 80Mi memory, got %+v", metrics)
+	}
+}
+
+func TestListPodMetricsWithoutMetricsServer(t *testing.T) {
+	podMetrics := schema.GroupResource{Group: "metrics.k8s.io", Resource: "pods"}
+	for name, apiErr := range map[string]error{
+		"not found":           apierrors.NewNotFound(podMetrics, ""),
+		"service unavailable": apierrors.NewServiceUnavailable("the server is currently unable to handle the request"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			metricsClientset := metricsfake.NewSimpleClientset()
+			metricsClientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, apiErr
+			})
+			client := NewClient(WithClientset(fake.NewSimpleClientset()), WithMetricsClientset(metricsClientset))
+
+			if _, err := client.ListPodMetrics(context.Background(), "default"); !errors.Is(err, ErrMetricsUnavailable) {
+				t.Errorf("expected ErrMetricsUnavailable, got %v", err)
+			}
+		})
+	}
+}
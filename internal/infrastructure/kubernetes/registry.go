@@ -0,0 +1,429 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	appsinformers "k8s.io/client-go/informers/apps/v1"
+	batchinformers "k8s.io/client-go/informers/batch/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/informers/internalinterfaces"
+	networkinginformers "k8s.io/client-go/informers/networking/v1"
+	"k8s.io/client-go/kubernetes"
+	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
+	batchv1client "k8s.io/client-go/kubernetes/typed/batch/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	networkingv1client "k8s.io/client-go/kubernetes/typed/networking/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s-controller/internal/infrastructure/metrics"
+	"k8s-controller/internal/infrastructure/tracing"
+)
+
+// resourceType describes how the client watches, lists and gets one kind of resource.
+// Adding support for a new resource type only needs a new entry in resourceTypes.
+type resourceType struct {
+	// Kind is the object kind, e.g. Deployment
+	Kind string
+	// Resource is the plural API resource name, e.g. deployments
+	Resource string
+	// ShortNames are the kubectl abbreviations of the type, e.g. deploy
+	ShortNames []string
+	// Group is the API group, empty for the core group
+	Group string
+	// Version is the API version served for the type
+	Version string
+	// object is an empty instance used for resync configuration and kind detection
+	object metav1.Object
+	// informer returns the shared informer for the type from the factory, creating it if needed
+	informer func(factory informers.SharedInformerFactory) cache.SharedIndexInformer
+	// filteredInformer creates an informer for the type whose list and watch requests are
+	// changed by tweak, used for resources with their own label selector
+	filteredInformer func(clientset kubernetes.Interface, namespace string, resync time.Duration, indexers cache.Indexers, tweak internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer
+	// client returns the API client of the type in the namespace
+	client func(clientset kubernetes.Interface, namespace string) objectClient
+}
+
+// objectClient lists and gets the objects of one resource type in a namespace
+type objectClient interface {
+	list(ctx context.Context, opts metav1.ListOptions) ([]runtime.Object, error)
+	get(ctx context.Context, name string) (runtime.Object, error)
+}
+
+// typedClient is the part of a typed client-go client, such as DeploymentInterface, used by
+// objectClient. T is the object type and L its list type.
+type typedClient[T, L runtime.Object] interface {
+	List(ctx context.Context, opts metav1.ListOptions) (L, error)
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+}
+
+// typedObjectClient adapts a typed client-go client to objectClient
+type typedObjectClient[T, L runtime.Object, C typedClient[T, L]] struct {
+	client C
+}
+
+// list lists the objects, returning pointers into the list's items
+func (tc typedObjectClient[T, L, C]) list(ctx context.Context, opts metav1.ListOptions) ([]runtime.Object, error) {
+	list, err := tc.client.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return meta.ExtractList(list)
+}
+
+// get gets a single object
+func (tc typedObjectClient[T, L, C]) get(ctx context.Context, name string) (runtime.Object, error) {
+	return tc.client.Get(ctx, name, metav1.GetOptions{})
+}
+
+// typed returns the client function of a resource type from a function selecting its typed
+// client-go client
+func typed[T, L runtime.Object, C typedClient[T, L]](client func(kubernetes.Interface, string) C) func(kubernetes.Interface, string) objectClient {
+	return func(clientset kubernetes.Interface, namespace string) objectClient {
+		return typedObjectClient[T, L, C]{client: client(clientset, namespace)}
+	}
+}
+
+// namespaceIndexers returns the indexers the informer factory gives its informers
+func namespaceIndexers() cache.Indexers {
+	return cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+}
+
+// resourceTypes lists every resource type the client supports
+var resourceTypes = []resourceType{
+	{
+		Kind:       "Deployment",
+		Resource:   "deployments",
+		ShortNames: []string{"deploy"},
+		Group:      "apps",
+		Version:    "v1",
+		object:     &appsv1.Deployment{},
+		informer: func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+			return factory.Apps().V1().Deployments().Informer()
+		},
+		filteredInformer: appsinformers.NewFilteredDeploymentInformer,
+		client: typed(func(c kubernetes.Interface, namespace string) appsv1client.DeploymentInterface {
+			return c.AppsV1().Deployments(namespace)
+		}),
+	},
+	{
+		Kind:       "Service",
+		Version:    "v1",
+		Resource:   "services",
+		ShortNames: []string{"svc"},
+		object:     &corev1.Service{},
+		informer: func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+			return factory.Core().V1().Services().Informer()
+		},
+		filteredInformer: coreinformers.NewFilteredServiceInformer,
+		client: typed(func(c kubernetes.Interface, namespace string) corev1client.ServiceInterface {
+			return c.CoreV1().Services(namespace)
+		}),
+	},
+	{
+		Kind:       "Pod",
+		Version:    "v1",
+		Resource:   "pods",
+		ShortNames: []string{"po"},
+		object:     &corev1.Pod{},
+		informer: func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+			return factory.Core().V1().Pods().Informer()
+		},
+		filteredInformer: coreinformers.NewFilteredPodInformer,
+		client: typed(func(c kubernetes.Interface, namespace string) corev1client.PodInterface {
+			return c.CoreV1().Pods(namespace)
+		}),
+	},
+	{
+		Kind:       "ConfigMap",
+		Version:    "v1",
+		Resource:   "configmaps",
+		ShortNames: []string{"cm"},
+		object:     &corev1.ConfigMap{},
+		informer: func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+			return factory.Core().V1().ConfigMaps().Informer()
+		},
+		filteredInformer: coreinformers.NewFilteredConfigMapInformer,
+		client: typed(func(c kubernetes.Interface, namespace string) corev1client.ConfigMapInterface {
+			return c.CoreV1().ConfigMaps(namespace)
+		}),
+	},
+	{
+		Kind:       "Ingress",
+		Resource:   "ingresses",
+		ShortNames: []string{"ing"},
+		Group:      "networking.k8s.io",
+		Version:    "v1",
+		object:     &networkingv1.Ingress{},
+		informer: func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+			return factory.Networking().V1().Ingresses().Informer()
+		},
+		filteredInformer: networkinginformers.NewFilteredIngressInformer,
+		client: typed(func(c kubernetes.Interface, namespace string) networkingv1client.IngressInterface {
+			return c.NetworkingV1().Ingresses(namespace)
+		}),
+	},
+	{
+		Kind:     "Job",
+		Resource: "jobs",
+		Group:    "batch",
+		Version:  "v1",
+		object:   &batchv1.Job{},
+		informer: func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+			return factory.Batch().V1().Jobs().Informer()
+		},
+		filteredInformer: batchinformers.NewFilteredJobInformer,
+		client: typed(func(c kubernetes.Interface, namespace string) batchv1client.JobInterface {
+			return c.BatchV1().Jobs(namespace)
+		}),
+	},
+	{
+		Kind:       "CronJob",
+		Resource:   "cronjobs",
+		ShortNames: []string{"cj"},
+		Group:      "batch",
+		Version:    "v1",
+		object:     &batchv1.CronJob{},
+		informer: func(factory informers.SharedInformerFactory) cache.SharedIndexInformer {
+			return factory.Batch().V1().CronJobs().Informer()
+		},
+		filteredInformer: batchinformers.NewFilteredCronJobInformer,
+		client: typed(func(c kubernetes.Interface, namespace string) batchv1client.CronJobInterface {
+			return c.BatchV1().CronJobs(namespace)
+		}),
+	},
+}
+
+// APIVersion returns the apiVersion of the type, e.g. apps/v1 or v1
+func (rt resourceType) APIVersion() string {
+	if rt.Group == "" {
+		return rt.Version
+	}
+	return rt.Group + "/" + rt.Version
+}
+
+// lookupResourceType finds a resource type by kind, plural or singular resource name, ignoring case
+func lookupResourceType(name string) (resourceType, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, rt := range resourceTypes {
+		if name == strings.ToLower(rt.Kind) || name == rt.Resource || slices.Contains(rt.ShortNames, name) {
+			return rt, true
+		}
+	}
+	return resourceType{}, false
+}
+
+// NormalizeResourceType maps a kind, singular, plural or short name such as po, svc, deploy or cm
+// to the plural resource name used throughout the client, e.g. pods
+func NormalizeResourceType(name string) (string, bool) {
+	rt, ok := lookupResourceType(name)
+	if !ok {
+		return strings.ToLower(strings.TrimSpace(name)), false
+	}
+	return rt.Resource, true
+}
+
+// NormalizeResourceTypes normalizes every name and drops duplicates, so "po,pods" watches pods
+// once. Unsupported names are kept, lowercased, for ValidateResourceTypes to report.
+func NormalizeResourceTypes(names []string) []string {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		resource, _ := NormalizeResourceType(name)
+		if resource != "" && !slices.Contains(normalized, resource) {
+			normalized = append(normalized, resource)
+		}
+	}
+	return normalized
+}
+
+// ResourceAliases returns the other names the resource type is known by: its plural resource
+// name, singular kind and short names, leaving out name itself
+func ResourceAliases(name string) []string {
+	rt, ok := lookupResourceType(name)
+	if !ok {
+		return nil
+	}
+
+	var aliases []string
+	for _, alias := range append([]string{rt.Resource, strings.ToLower(rt.Kind)}, rt.ShortNames...) {
+		if alias != name {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+// mustLookupResourceType returns the resource type or an error naming the unsupported type
+func mustLookupResourceType(name string) (resourceType, error) {
+	rt, ok := lookupResourceType(name)
+	if !ok {
+		return resourceType{}, fmt.Errorf("unsupported resource type %q", name)
+	}
+	return rt, nil
+}
+
+// resourceTypeForObject finds the resource type matching the Go type of obj
+func resourceTypeForObject(obj interface{}) (resourceType, bool) {
+	objType := reflect.TypeOf(obj)
+	for _, rt := range resourceTypes {
+		if reflect.TypeOf(rt.object) == objType {
+			return rt, true
+		}
+	}
+	return resourceType{}, false
+}
+
+// listObjects lists objects of the resource type in the namespace that match the label selector.
+// Objects come from the informer cache once it has synced, otherwise from the API.
+func (c *kubeClient) listObjects(ctx context.Context, resource, namespace, selector string) (_ []runtime.Object, err error) {
+	if c.clientset == nil {
+		return nil, fmt.Errorf("kubernetes client not connected")
+	}
+
+	rt, err := mustLookupResourceType(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := tracing.Start(ctx, "List "+rt.Resource, rt.Kind, namespace, "")
+	if selector != "" {
+		span.SetAttributes(attribute.String("k8s.label_selector", selector))
+	}
+	defer func() { tracing.End(span, err) }()
+
+	labelSelector, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", selector, err)
+	}
+
+	// A single equality on an indexed label is answered from the index without scanning the store
+	if key, value, ok := c.indexedLabel(labelSelector); ok {
+		objects, indexed, err := c.listObjectsByLabelIndex(rt, namespace, key, value)
+		if err != nil {
+			loggerFor(ctx).Error("Failed to list from label index", "resource", rt.Resource, "label", key, "error", err, "namespace", namespace)
+			return nil, err
+		}
+		if indexed {
+			span.SetAttributes(tracing.SourceKey.String("label-index"))
+			return objects, nil
+		}
+	}
+
+	// Only trust the cache once it has synced, an unsynced cache may be empty or partial
+	if informer, ok := c.syncedInformer(namespace, rt); ok {
+		var objects []runtime.Object
+		err := cache.ListAllByNamespace(informer.GetIndexer(), namespace, labelSelector, func(obj interface{}) {
+			if runtimeObj, ok := UnwrapTombstone(obj).(runtime.Object); ok {
+				objects = append(objects, runtimeObj)
+			}
+		})
+		if err != nil {
+			loggerFor(ctx).Error("Failed to list from cache", "resource", rt.Resource, "error", err, "namespace", namespace)
+			return nil, err
+		}
+		span.SetAttributes(tracing.SourceKey.String("informer-cache"))
+		return objects, nil
+	}
+
+	apiSelector := c.restrictToWatchSelector(rt.Resource, labelSelector.String())
+	key := listCacheKey{resource: rt.Resource, namespace: namespace, selector: apiSelector}
+	if c.listCache != nil && !listCacheBypassed(ctx) {
+		if objects, ok := c.listCache.get(key); ok {
+			metrics.ListCacheHits.WithLabelValues(rt.Resource).Inc()
+			span.SetAttributes(tracing.SourceKey.String("list-cache"))
+			return objects, nil
+		}
+	}
+
+	var generation uint64
+	if c.listCache != nil {
+		generation = c.listCache.currentGeneration()
+	}
+
+	loggerFor(ctx).Debug("No synced informer cache, listing from the API", "resource", rt.Resource, "namespace", namespace)
+	span.SetAttributes(tracing.SourceKey.String("api"))
+	objects, err := rt.client(c.clientset, namespace).list(ctx, metav1.ListOptions{LabelSelector: apiSelector})
+	if err != nil {
+		loggerFor(ctx).Error("Failed to list resources", "resource", rt.Resource, "error", err, "namespace", namespace)
+		return nil, err
+	}
+	if c.listCache != nil {
+		metrics.ListCacheMisses.WithLabelValues(rt.Resource).Inc()
+		c.listCache.put(key, generation, objects)
+	}
+	return objects, nil
+}
+
+// getObject gets a single object of the resource type, preferring the synced informer cache
+func (c *kubeClient) getObject(ctx context.Context, resource, namespace, name string) (_ runtime.Object, err error) {
+	if c.clientset == nil {
+		return nil, fmt.Errorf("kubernetes client not connected")
+	}
+
+	rt, err := mustLookupResourceType(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := tracing.Start(ctx, "Get "+rt.Resource, rt.Kind, namespace, name)
+	defer func() { tracing.End(span, err) }()
+
+	if informer, ok := c.syncedInformer(namespace, rt); ok {
+		obj, exists, err := informer.GetIndexer().GetByKey(namespace + "/" + name)
+		if err == nil && exists {
+			if runtimeObj, ok := UnwrapTombstone(obj).(runtime.Object); ok {
+				span.SetAttributes(tracing.SourceKey.String("informer-cache"))
+				return runtimeObj, nil
+			}
+		}
+		loggerFor(ctx).Debug("Object not found in cache, falling back to direct API call", "resource", rt.Resource, "name", name, "namespace", namespace)
+	}
+
+	span.SetAttributes(tracing.SourceKey.String("api"))
+	obj, err := rt.client(c.clientset, namespace).get(ctx, name)
+	if err != nil {
+		loggerFor(ctx).Error("Failed to get resource", "resource", rt.Resource, "error", err, "name", name, "namespace", namespace)
+		return nil, err
+	}
+	return obj, nil
+}
+
+// SupportedResourceTypes returns the plural names of all resource types that can be watched
+func SupportedResourceTypes() []string {
+	names := make([]string, 0, len(resourceTypes))
+	for _, rt := range resourceTypes {
+		names = append(names, rt.Resource)
+	}
+	return names
+}
+
+// ValidateResourceTypes returns an error naming every unsupported resource type
+// together with the list of supported ones
+func ValidateResourceTypes(resources []string) error {
+	var unknown []string
+	for _, resource := range resources {
+		if _, ok := lookupResourceType(resource); !ok {
+			unknown = append(unknown, resource)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("unsupported resource types %s (supported: %s)",
+			strings.Join(unknown, ", "), strings.Join(SupportedResourceTypes(), ", "))
+	}
+	return nil
+}
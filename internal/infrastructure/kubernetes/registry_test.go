@@ -0,0 +1,63 @@
+package kubernetes
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLookupResourceTypeAliases(t *testing.T) {
+	for _, name := range []string{"deployments", "deployment", "Deployment", " DEPLOYMENTS "} {
+		rt, ok := lookupResourceType(name)
+		if !ok || rt.Resource != "deployments" {
+			t.Errorf("lookupResourceType(%q) = %q, %v; want deployments", name, rt.Resource, ok)
+		}
+	}
+
+	if _, ok := lookupResourceType("statefulsets"); ok {
+		t.Error("expected statefulsets to be unsupported")
+	}
+}
+
+func TestValidateResourceTypes(t *testing.T) {
+	if err := ValidateResourceTypes([]string{"deployments", "Service", "pod"}); err != nil {
+		t.Fatalf("unexpected error for supported types: %v", err)
+	}
+
+	err := ValidateResourceTypes([]string{"deployments", "deploymnets", "statefulsets"})
+	if err == nil {
+		t.Fatal("expected an error for unsupported types")
+	}
+	for _, want := range []string{"deploymnets", "statefulsets", "supported: deployments, services, pods, configmaps, ingresses, jobs, cronjobs"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestNormalizeResourceTypes(t *testing.T) {
+	for name, want := range map[string]string{
+		"po":       "pods",
+		"svc":      "services",
+		"deploy":   "deployments",
+		"cm":       "configmaps",
+		"ingress":  "ingresses",
+		"CronJobs": "cronjobs",
+	} {
+		if got, ok := NormalizeResourceType(name); !ok || got != want {
+			t.Errorf("NormalizeResourceType(%q) = %q, %v; want %q", name, got, ok, want)
+		}
+	}
+
+	got := NormalizeResourceTypes([]string{"po", "pods", "svc", "Statefulsets"})
+	if want := []string{"pods", "services", "statefulsets"}; !slices.Equal(got, want) {
+		t.Errorf("NormalizeResourceTypes() = %v, want %v", got, want)
+	}
+}
+
+func TestResourceAliases(t *testing.T) {
+	got := ResourceAliases("pod")
+	if want := []string{"pods", "po"}; !slices.Equal(got, want) {
+		t.Errorf("ResourceAliases(pod) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,29 @@
+package kubernetes
+
+import (
+	"context"
+	"log/slog"
+)
+
+// requestIDKey is the context key of the HTTP request ID
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the ID of the HTTP request it serves. Client methods
+// called with it add the ID to their log lines as request_id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored by WithRequestID, or "" if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// loggerFor returns the default logger, tagged with the request ID of ctx when it has one
+func loggerFor(ctx context.Context) *slog.Logger {
+	if id := RequestIDFromContext(ctx); id != "" {
+		return slog.Default().With("request_id", id)
+	}
+	return slog.Default()
+}
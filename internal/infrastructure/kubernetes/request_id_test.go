@@ -0,0 +1,30 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClientLogsIncludeRequestID(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	client := newTestClient(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}})
+
+	ctx := WithRequestID(context.Background(), "req-42")
+	if _, err := client.GetDeployment(ctx, "default", "web"); err != nil {
+		t.Fatalf("GetDeployment failed: %v", err)
+	}
+
+	if !strings.Contains(logs.String(), "request_id=req-42") {
+		t.Errorf("expected client logs to carry the request ID, got:\n%s", logs.String())
+	}
+}
@@ -0,0 +1,38 @@
+package kubernetes
+
+import (
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// ConnectionOptions select the cluster to connect to and the client-side rate limits
+type ConnectionOptions struct {
+	// Kubeconfig is the kubeconfig file; empty uses $KUBECONFIG, ~/.kube/config or the in-cluster config
+	Kubeconfig string
+	// Context is the kubeconfig context; empty uses the current context
+	Context string
+	// QPS and Burst limit requests to the API server; 0 keeps the client-go defaults
+	QPS   float32
+	Burst int
+}
+
+// BuildRESTConfig builds the rest config described by the options. Both the informer client
+// and the controller-runtime manager use it, so they talk to the same cluster.
+func BuildRESTConfig(opts ConnectionOptions) (*rest.Config, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = opts.Kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: opts.Context}
+
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.QPS > 0 {
+		config.QPS = opts.QPS
+	}
+	if opts.Burst > 0 {
+		config.Burst = opts.Burst
+	}
+	return config, nil
+}
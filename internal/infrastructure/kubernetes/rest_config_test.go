@@ -0,0 +1,62 @@
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example:6443
+- name: prod
+  cluster:
+    server: https://prod.example:6443
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    user: admin
+- name: prod
+  context:
+    cluster: prod
+    user: admin
+users:
+- name: admin
+  user:
+    token: secret
+`
+
+func TestBuildRESTConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(testKubeconfig), 0o600); err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+
+	config, err := BuildRESTConfig(ConnectionOptions{Kubeconfig: path})
+	if err != nil {
+		t.Fatalf("BuildRESTConfig failed: %v", err)
+	}
+	if config.Host != "https://dev.example:6443" {
+		t.Errorf("expected the current context's cluster, got %s", config.Host)
+	}
+
+	config, err = BuildRESTConfig(ConnectionOptions{Kubeconfig: path, Context: "prod", QPS: 50, Burst: 100})
+	if err != nil {
+		t.Fatalf("BuildRESTConfig failed: %v", err)
+	}
+	if config.Host != "https://prod.example:6443" {
+		t.Errorf("expected the prod cluster, got %s", config.Host)
+	}
+	if config.QPS != 50 || config.Burst != 100 {
+		t.Errorf("expected QPS 50 and burst 100, got %g and %d", config.QPS, config.Burst)
+	}
+
+	if _, err := BuildRESTConfig(ConnectionOptions{Kubeconfig: path, Context: "missing"}); err == nil {
+		t.Error("expected an error for an unknown context")
+	}
+}
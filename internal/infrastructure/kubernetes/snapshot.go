@@ -0,0 +1,138 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s-controller/internal/domain"
+)
+
+// SnapshotInformer is a read-only deployment cache backed by its own informer factory.
+// It runs separately from the reconcile informers, typically with a longer resync period,
+// so reporting code can read consistent snapshots without extra API load.
+type SnapshotInformer struct {
+	name         string
+	namespace    string
+	resyncPeriod time.Duration
+	factory      informers.SharedInformerFactory
+	informer     cache.SharedIndexInformer
+	lister       appslisters.DeploymentLister
+	// stop stops the factory, it is closed when the client stops
+	stop *informerStop
+}
+
+// NewSnapshotInformer creates and starts a named snapshot informer for the namespace,
+// waiting for its cache to sync. An empty namespace watches all namespaces.
+// Calling it again with the same name returns the existing informer.
+func (c *kubeClient) NewSnapshotInformer(ctx context.Context, name, namespace string, resyncPeriod time.Duration) (*SnapshotInformer, error) {
+	if c.clientset == nil {
+		return nil, fmt.Errorf("kubernetes client not connected")
+	}
+
+	if existing, ok := c.snapshotInformer(name); ok {
+		return existing, nil
+	}
+
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		c.clientset,
+		resyncPeriod,
+		informers.WithNamespace(namespace),
+	)
+	deployments := factory.Apps().V1().Deployments()
+
+	snapshot := &SnapshotInformer{
+		name:         name,
+		namespace:    namespace,
+		resyncPeriod: resyncPeriod,
+		factory:      factory,
+		informer:     deployments.Informer(),
+		lister:       deployments.Lister(),
+		stop:         &informerStop{ch: make(chan struct{})},
+	}
+
+	// The factory runs on its own channel, so one that fails to sync can be stopped alone
+	go func() {
+		select {
+		case <-c.stopCh:
+			snapshot.stop.close()
+		case <-snapshot.stop.ch:
+		}
+	}()
+	factory.Start(snapshot.stop.ch)
+
+	// Sync without holding snapshotMu, so a slow informer doesn't block other snapshot informers
+	if !cache.WaitForCacheSync(ctx.Done(), snapshot.informer.HasSynced) {
+		snapshot.shutdown()
+		return nil, fmt.Errorf("timed out waiting for snapshot informer %q to sync", name)
+	}
+
+	c.snapshotMu.Lock()
+	select {
+	case <-c.stopCh:
+		// Stop has already shut down the registered informers and would miss this one
+		c.snapshotMu.Unlock()
+		snapshot.shutdown()
+		return nil, fmt.Errorf("kubernetes client stopped")
+	default:
+	}
+	existing, ok := c.snapshotInformers[name]
+	if !ok {
+		c.snapshotInformers[name] = snapshot
+	}
+	c.snapshotMu.Unlock()
+
+	// Another caller created the informer with this name while this one was syncing
+	if ok {
+		snapshot.shutdown()
+		return existing, nil
+	}
+
+	slog.Info("Snapshot informer started", "name", name, "namespace", namespace, "resync", resyncPeriod)
+	return snapshot, nil
+}
+
+// snapshotInformer returns the snapshot informer with the given name, if one exists
+func (c *kubeClient) snapshotInformer(name string) (*SnapshotInformer, bool) {
+	c.snapshotMu.Lock()
+	defer c.snapshotMu.Unlock()
+
+	snapshot, ok := c.snapshotInformers[name]
+	return snapshot, ok
+}
+
+// shutdown stops the factory and waits for its informer goroutines to exit
+func (s *SnapshotInformer) shutdown() {
+	s.stop.close()
+	s.factory.Shutdown()
+}
+
+// Name returns the name of the snapshot informer
+func (s *SnapshotInformer) Name() string {
+	return s.name
+}
+
+// HasSynced reports whether the snapshot cache has completed its initial sync
+func (s *SnapshotInformer) HasSynced() bool {
+	return s.informer.HasSynced()
+}
+
+// Snapshot returns all cached deployments at once
+func (s *SnapshotInformer) Snapshot() ([]domain.Deployment, error) {
+	deploymentList, err := s.lister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	deployments := make([]domain.Deployment, 0, len(deploymentList))
+	for _, dep := range deploymentList {
+		deployments = append(deployments, ToDomainDeployment(dep))
+	}
+	return deployments, nil
+}
@@ -0,0 +1,112 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestSnapshotInformer(t *testing.T) {
+	replicas := int32(2)
+	client := newTestClient(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "other"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+	)
+	defer client.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	snapshot, err := client.NewSnapshotInformer(ctx, "reports", "", time.Hour)
+	if err != nil {
+		t.Fatalf("NewSnapshotInformer failed: %v", err)
+	}
+
+	deployments, err := snapshot.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	if len(deployments) != 2 {
+		t.Errorf("expected 2 deployments across namespaces, got %d", len(deployments))
+	}
+
+	// The snapshot informer is separate from the reconcile informers
+	if len(client.snapshotInformerFactories()) != 0 {
+		t.Error("expected snapshot informer not to register a shared informer factory")
+	}
+
+	again, err := client.NewSnapshotInformer(ctx, "reports", "", time.Hour)
+	if err != nil || again != snapshot {
+		t.Errorf("expected the existing snapshot informer to be returned, got %v, %v", again, err)
+	}
+}
+
+func TestSnapshotInformerSyncFailureStopsItsFactory(t *testing.T) {
+	client := newTestClient(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"}})
+	defer client.Stop()
+
+	// Deployments in the slow namespace never list, so their informer never syncs
+	var listMu sync.Mutex
+	slowLists := 0
+	client.clientset.(*fake.Clientset).PrependReactor("list", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() != "slow" {
+			return false, nil, nil
+		}
+		listMu.Lock()
+		defer listMu.Unlock()
+		slowLists++
+		return true, nil, errors.New("API server unavailable")
+	})
+
+	slowCtx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	failed := make(chan error, 1)
+	go func() {
+		_, err := client.NewSnapshotInformer(slowCtx, "slow", "slow", time.Hour)
+		failed <- err
+	}()
+
+	// A slow informer does not block creating others
+	ctx, cancelFast := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFast()
+	if _, err := client.NewSnapshotInformer(ctx, "fast", "default", time.Hour); err != nil {
+		t.Fatalf("NewSnapshotInformer failed: %v", err)
+	}
+	select {
+	case err := <-failed:
+		t.Fatalf("expected the slow informer to still be syncing, got %v", err)
+	default:
+	}
+
+	if err := <-failed; err == nil {
+		t.Fatal("expected the slow informer to fail to sync")
+	}
+	if _, ok := client.snapshotInformer("slow"); ok {
+		t.Error("expected the failed informer not to be registered")
+	}
+
+	// The failed informer's factory was stopped, so it stops listing
+	listMu.Lock()
+	calls := slowLists
+	listMu.Unlock()
+	time.Sleep(1500 * time.Millisecond)
+	listMu.Lock()
+	defer listMu.Unlock()
+	if slowLists != calls {
+		t.Errorf("expected no more lists after the sync failed, got %d more", slowLists-calls)
+	}
+}
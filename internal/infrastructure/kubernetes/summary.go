@@ -0,0 +1,43 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"k8s-controller/internal/domain"
+)
+
+// SummarizeResources counts deployments, services, pods and config maps in the namespace.
+// Counts come from synced informer caches when available, otherwise from the API.
+func (c *kubeClient) SummarizeResources(ctx context.Context, namespace string) (domain.ResourceSummary, error) {
+	if c.clientset == nil {
+		return domain.ResourceSummary{}, fmt.Errorf("kubernetes client not connected")
+	}
+
+	var summary domain.ResourceSummary
+	var err error
+
+	if summary.Deployments, err = c.countResource(ctx, namespace, "deployments"); err != nil {
+		return domain.ResourceSummary{}, err
+	}
+	if summary.Services, err = c.countResource(ctx, namespace, "services"); err != nil {
+		return domain.ResourceSummary{}, err
+	}
+	if summary.Pods, err = c.countResource(ctx, namespace, "pods"); err != nil {
+		return domain.ResourceSummary{}, err
+	}
+	if summary.ConfigMaps, err = c.countResource(ctx, namespace, "configmaps"); err != nil {
+		return domain.ResourceSummary{}, err
+	}
+
+	return summary, nil
+}
+
+// countResource counts a single resource type in the namespace
+func (c *kubeClient) countResource(ctx context.Context, namespace, resource string) (int, error) {
+	objects, err := c.listObjects(ctx, resource, namespace, "")
+	if err != nil {
+		return 0, err
+	}
+	return len(objects), nil
+}
@@ -0,0 +1,66 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s-controller/internal/domain"
+)
+
+func TestSummarizeResources(t *testing.T) {
+	client := newTestClient(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "default"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-1", Namespace: "other"}},
+	)
+	defer client.Stop()
+	ctx := context.Background()
+
+	want := domain.ResourceSummary{Deployments: 1, Services: 1, Pods: 2, ConfigMaps: 1}
+
+	// Without informers every count comes from the API
+	summary, err := client.SummarizeResources(ctx, "default")
+	if err != nil {
+		t.Fatalf("SummarizeResources failed: %v", err)
+	}
+	if summary != want {
+		t.Errorf("expected %+v, got %+v", want, summary)
+	}
+
+	// With informers running the cached counts must match
+	if err := client.startInformers(ctx, []string{"default"}, []string{"deployments", "pods"}); err != nil {
+		t.Fatalf("startInformers failed: %v", err)
+	}
+	pods, _ := lookupResourceType("pods")
+	services, _ := lookupResourceType("services")
+	waitFor(t, func() bool {
+		_, ok := client.syncedInformer("default", pods)
+		return ok
+	})
+	if _, ok := client.syncedInformer("default", services); ok {
+		t.Error("expected no synced informer for services")
+	}
+
+	summary, err = client.SummarizeResources(ctx, "default")
+	if err != nil {
+		t.Fatalf("SummarizeResources failed: %v", err)
+	}
+	if summary != want {
+		t.Errorf("expected %+v, got %+v", want, summary)
+	}
+
+	other, err := client.SummarizeResources(ctx, "other")
+	if err != nil {
+		t.Fatalf("SummarizeResources failed: %v", err)
+	}
+	if total := summary.Add(other); total.Pods != 3 {
+		t.Errorf("expected 3 pods in total, got %d", total.Pods)
+	}
+}
@@ -0,0 +1,65 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s-controller/internal/domain"
+)
+
+// GetDeploymentTree returns a deployment with the ReplicaSets it controls and the pods controlled
+// by each of them. Ownership is matched by owner reference UID, so pods and ReplicaSets that only
+// share the deployment's labels are left out.
+func (c *kubeClient) GetDeploymentTree(ctx context.Context, namespace, name string) (domain.DeploymentTree, error) {
+	loggerFor(ctx).Debug("Getting deployment tree", "name", name, "namespace", namespace)
+
+	obj, err := c.getObject(ctx, "deployments", namespace, name)
+	if err != nil {
+		return domain.DeploymentTree{}, err
+	}
+	deployment := obj.(*appsv1.Deployment)
+
+	replicaSets, err := c.ownedReplicaSets(ctx, deployment)
+	if err != nil {
+		return domain.DeploymentTree{}, err
+	}
+
+	// Pods carry the deployment's labels plus the pod-template-hash, so the deployment's selector
+	// finds the pods of every revision
+	selector := ""
+	if deployment.Spec.Selector != nil {
+		s, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return domain.DeploymentTree{}, fmt.Errorf("invalid selector on deployment %s/%s: %w", namespace, name, err)
+		}
+		selector = s.String()
+	}
+	pods, err := c.listObjects(ctx, "pods", namespace, selector)
+	if err != nil {
+		return domain.DeploymentTree{}, err
+	}
+
+	tree := domain.DeploymentTree{
+		Deployment:  ToDomainDeployment(deployment),
+		ReplicaSets: make([]domain.ReplicaSetTree, 0, len(replicaSets)),
+	}
+	for _, rs := range replicaSets {
+		node := domain.ReplicaSetTree{DeploymentRevision: toDomainRevision(rs)}
+		for _, obj := range pods {
+			pod := obj.(*corev1.Pod)
+			if metav1.IsControlledBy(pod, rs) {
+				node.Pods = append(node.Pods, toDomainPod(pod))
+			}
+		}
+		sort.Slice(node.Pods, func(i, j int) bool { return node.Pods[i].Name < node.Pods[j].Name })
+		tree.ReplicaSets = append(tree.ReplicaSets, node)
+	}
+
+	loggerFor(ctx).Info("Successfully got deployment tree", "replica_sets", len(tree.ReplicaSets), "name", name, "namespace", namespace)
+	return tree, nil
+}
@@ -0,0 +1,69 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestGetDeploymentTree(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: types.UID("web-uid")},
+		Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+	}
+	replicaSet := func(name, revision string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				UID:             types.UID(name + "-uid"),
+				Labels:          map[string]string{"app": "web"},
+				Annotations:     map[string]string{revisionAnnotation: revision},
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(deployment, appsv1.SchemeGroupVersion.WithKind("Deployment"))},
+			},
+		}
+	}
+	pod := func(name string, owner *appsv1.ReplicaSet) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "web"}}}
+		if owner != nil {
+			p.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(owner, appsv1.SchemeGroupVersion.WithKind("ReplicaSet"))}
+		}
+		return p
+	}
+	current, previous := replicaSet("web-2", "2"), replicaSet("web-1", "1")
+
+	client := newTestClient(
+		deployment, current, previous,
+		pod("web-2-b", current), pod("web-2-a", current),
+		pod("web-1-a", previous),
+		pod("stray", nil),
+	)
+
+	tree, err := client.GetDeploymentTree(context.Background(), "default", "web")
+	if err != nil {
+		t.Fatalf("GetDeploymentTree failed: %v", err)
+	}
+	if tree.Deployment.Name != "web" {
+		t.Errorf("expected deployment web, got %q", tree.Deployment.Name)
+	}
+
+	want := map[string][]string{"web-2": {"web-2-a", "web-2-b"}, "web-1": {"web-1-a"}}
+	if len(tree.ReplicaSets) != 2 || tree.ReplicaSets[0].ReplicaSet != "web-2" || tree.ReplicaSets[1].ReplicaSet != "web-1" {
+		t.Fatalf("expected replica sets web-2, web-1, got %+v", tree.ReplicaSets)
+	}
+	for _, rs := range tree.ReplicaSets {
+		if len(rs.Pods) != len(want[rs.ReplicaSet]) {
+			t.Errorf("expected pods %v under %s, got %+v", want[rs.ReplicaSet], rs.ReplicaSet, rs.Pods)
+			continue
+		}
+		for i, name := range want[rs.ReplicaSet] {
+			if rs.Pods[i].Name != name {
+				t.Errorf("expected pod %s at %d under %s, got %s", name, i, rs.ReplicaSet, rs.Pods[i].Name)
+			}
+		}
+	}
+}
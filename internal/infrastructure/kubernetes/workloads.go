@@ -0,0 +1,288 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s-controller/internal/domain"
+)
+
+// ListServices retrieves services in the namespace matching the label selector
+func (c *kubeClient) ListServices(ctx context.Context, namespace, selector string) ([]domain.Service, error) {
+	loggerFor(ctx).Debug("Listing services", "namespace", namespace, "selector", selector)
+
+	objects, err := c.listObjects(ctx, "services", namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]domain.Service, 0, len(objects))
+	for _, obj := range objects {
+		services = append(services, toDomainService(obj.(*corev1.Service)))
+	}
+
+	loggerFor(ctx).Info("Successfully listed services", "count", len(services), "namespace", namespace)
+	return services, nil
+}
+
+// ListPods retrieves pods in the namespace matching the label selector
+func (c *kubeClient) ListPods(ctx context.Context, namespace, selector string) ([]domain.Pod, error) {
+	loggerFor(ctx).Debug("Listing pods", "namespace", namespace, "selector", selector)
+
+	objects, err := c.listObjects(ctx, "pods", namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	pods := make([]domain.Pod, 0, len(objects))
+	for _, obj := range objects {
+		pods = append(pods, toDomainPod(obj.(*corev1.Pod)))
+	}
+
+	loggerFor(ctx).Info("Successfully listed pods", "count", len(pods), "namespace", namespace)
+	return pods, nil
+}
+
+// ListIngresses retrieves ingresses in the namespace matching the label selector
+func (c *kubeClient) ListIngresses(ctx context.Context, namespace, selector string) ([]domain.Ingress, error) {
+	loggerFor(ctx).Debug("Listing ingresses", "namespace", namespace, "selector", selector)
+
+	objects, err := c.listObjects(ctx, "ingresses", namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	ingresses := make([]domain.Ingress, 0, len(objects))
+	for _, obj := range objects {
+		ingresses = append(ingresses, toDomainIngress(obj.(*networkingv1.Ingress)))
+	}
+
+	loggerFor(ctx).Info("Successfully listed ingresses", "count", len(ingresses), "namespace", namespace)
+	return ingresses, nil
+}
+
+// GetIngress retrieves a single ingress
+func (c *kubeClient) GetIngress(ctx context.Context, namespace, name string) (domain.Ingress, error) {
+	loggerFor(ctx).Debug("Getting ingress", "name", name, "namespace", namespace)
+
+	obj, err := c.getObject(ctx, "ingresses", namespace, name)
+	if err != nil {
+		return domain.Ingress{}, err
+	}
+	return toDomainIngress(obj.(*networkingv1.Ingress)), nil
+}
+
+// ListJobs retrieves jobs in the namespace matching the label selector
+func (c *kubeClient) ListJobs(ctx context.Context, namespace, selector string) ([]domain.Job, error) {
+	loggerFor(ctx).Debug("Listing jobs", "namespace", namespace, "selector", selector)
+
+	objects, err := c.listObjects(ctx, "jobs", namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]domain.Job, 0, len(objects))
+	for _, obj := range objects {
+		jobs = append(jobs, toDomainJob(obj.(*batchv1.Job)))
+	}
+
+	loggerFor(ctx).Info("Successfully listed jobs", "count", len(jobs), "namespace", namespace)
+	return jobs, nil
+}
+
+// GetJob retrieves a single job
+func (c *kubeClient) GetJob(ctx context.Context, namespace, name string) (domain.Job, error) {
+	loggerFor(ctx).Debug("Getting job", "name", name, "namespace", namespace)
+
+	obj, err := c.getObject(ctx, "jobs", namespace, name)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	return toDomainJob(obj.(*batchv1.Job)), nil
+}
+
+// ListCronJobs retrieves cron jobs in the namespace matching the label selector
+func (c *kubeClient) ListCronJobs(ctx context.Context, namespace, selector string) ([]domain.CronJob, error) {
+	loggerFor(ctx).Debug("Listing cron jobs", "namespace", namespace, "selector", selector)
+
+	objects, err := c.listObjects(ctx, "cronjobs", namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	cronJobs := make([]domain.CronJob, 0, len(objects))
+	for _, obj := range objects {
+		cronJobs = append(cronJobs, toDomainCronJob(obj.(*batchv1.CronJob)))
+	}
+
+	loggerFor(ctx).Info("Successfully listed cron jobs", "count", len(cronJobs), "namespace", namespace)
+	return cronJobs, nil
+}
+
+// GetCronJob retrieves a single cron job
+func (c *kubeClient) GetCronJob(ctx context.Context, namespace, name string) (domain.CronJob, error) {
+	loggerFor(ctx).Debug("Getting cron job", "name", name, "namespace", namespace)
+
+	obj, err := c.getObject(ctx, "cronjobs", namespace, name)
+	if err != nil {
+		return domain.CronJob{}, err
+	}
+	return toDomainCronJob(obj.(*batchv1.CronJob)), nil
+}
+
+// toDomainService converts a Kubernetes service to the domain model
+func toDomainService(svc *corev1.Service) domain.Service {
+	ports := make([]string, 0, len(svc.Spec.Ports))
+	for _, port := range svc.Spec.Ports {
+		ports = append(ports, fmt.Sprintf("%d/%s", port.Port, port.Protocol))
+	}
+
+	return domain.Service{
+		Name:      svc.Name,
+		Namespace: svc.Namespace,
+		Type:      string(svc.Spec.Type),
+		ClusterIP: svc.Spec.ClusterIP,
+		Ports:     ports,
+		Labels:    svc.Labels,
+		CreatedAt: svc.CreationTimestamp.UTC(),
+	}
+}
+
+// toDomainPod converts a Kubernetes pod to the domain model
+func toDomainPod(pod *corev1.Pod) domain.Pod {
+	var ready, restarts int32
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Ready {
+			ready++
+		}
+		restarts += status.RestartCount
+	}
+
+	return domain.Pod{
+		Name:            pod.Name,
+		Namespace:       pod.Namespace,
+		Phase:           string(pod.Status.Phase),
+		ReadyContainers: ready,
+		TotalContainers: int32(len(pod.Spec.Containers)),
+		Restarts:        restarts,
+		NodeName:        pod.Spec.NodeName,
+		Labels:          pod.Labels,
+		CreatedAt:       pod.CreationTimestamp.UTC(),
+	}
+}
+
+// toDomainIngress converts a Kubernetes ingress to the domain model.
+// The default backend, if set, is reported as a path without a host.
+func toDomainIngress(ing *networkingv1.Ingress) domain.Ingress {
+	var className string
+	if ing.Spec.IngressClassName != nil {
+		className = *ing.Spec.IngressClassName
+	}
+
+	var hosts []string
+	var paths []domain.IngressPath
+	if backend := ing.Spec.DefaultBackend; backend != nil {
+		paths = append(paths, toDomainIngressPath("", "", *backend))
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host != "" {
+			hosts = append(hosts, rule.Host)
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			paths = append(paths, toDomainIngressPath(rule.Host, path.Path, path.Backend))
+		}
+	}
+
+	var tlsHosts []string
+	for _, tls := range ing.Spec.TLS {
+		tlsHosts = append(tlsHosts, tls.Hosts...)
+	}
+
+	return domain.Ingress{
+		Name:      ing.Name,
+		Namespace: ing.Namespace,
+		ClassName: className,
+		Hosts:     hosts,
+		Paths:     paths,
+		TLSHosts:  tlsHosts,
+		Labels:    ing.Labels,
+		CreatedAt: ing.CreationTimestamp.UTC(),
+	}
+}
+
+// toDomainIngressPath converts an ingress backend to a domain path.
+// Resource backends have no service and are reported by their kind and name.
+func toDomainIngressPath(host, path string, backend networkingv1.IngressBackend) domain.IngressPath {
+	result := domain.IngressPath{Host: host, Path: path}
+	switch {
+	case backend.Service != nil:
+		result.ServiceName = backend.Service.Name
+		if backend.Service.Port.Name != "" {
+			result.ServicePort = backend.Service.Port.Name
+		} else {
+			result.ServicePort = fmt.Sprintf("%d", backend.Service.Port.Number)
+		}
+	case backend.Resource != nil:
+		result.ServiceName = backend.Resource.Kind + "/" + backend.Resource.Name
+	}
+	return result
+}
+
+// toDomainJob converts a Kubernetes job to the domain model.
+// Completions and parallelism default to 1 when unset, as in the API.
+func toDomainJob(job *batchv1.Job) domain.Job {
+	completions, parallelism := int32(1), int32(1)
+	if job.Spec.Completions != nil {
+		completions = *job.Spec.Completions
+	}
+	if job.Spec.Parallelism != nil {
+		parallelism = *job.Spec.Parallelism
+	}
+
+	return domain.Job{
+		Name:           job.Name,
+		Namespace:      job.Namespace,
+		Completions:    completions,
+		Parallelism:    parallelism,
+		Active:         job.Status.Active,
+		Succeeded:      job.Status.Succeeded,
+		Failed:         job.Status.Failed,
+		StartTime:      toTimePtr(job.Status.StartTime),
+		CompletionTime: toTimePtr(job.Status.CompletionTime),
+		Labels:         job.Labels,
+		CreatedAt:      job.CreationTimestamp.UTC(),
+	}
+}
+
+// toDomainCronJob converts a Kubernetes cron job to the domain model
+func toDomainCronJob(cronJob *batchv1.CronJob) domain.CronJob {
+	return domain.CronJob{
+		Name:               cronJob.Name,
+		Namespace:          cronJob.Namespace,
+		Schedule:           cronJob.Spec.Schedule,
+		Suspend:            cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend,
+		Active:             int32(len(cronJob.Status.Active)),
+		LastScheduleTime:   toTimePtr(cronJob.Status.LastScheduleTime),
+		LastSuccessfulTime: toTimePtr(cronJob.Status.LastSuccessfulTime),
+		Labels:             cronJob.Labels,
+		CreatedAt:          cronJob.CreationTimestamp.UTC(),
+	}
+}
+
+// toTimePtr converts an optional API timestamp to UTC, keeping nil for unset times
+func toTimePtr(t *metav1.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
@@ -0,0 +1,118 @@
+package kubernetes
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s-controller/internal/domain"
+)
+
+func TestListIngresses(t *testing.T) {
+	className := "nginx"
+	pathType := networkingv1.PathTypePrefix
+	client := newTestClient(
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"app": "web"}},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: &className,
+				TLS:              []networkingv1.IngressTLS{{Hosts: []string{"web.example.com"}}},
+				Rules: []networkingv1.IngressRule{{
+					Host: "web.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{
+							{Path: "/", PathType: &pathType, Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{Name: "web", Port: networkingv1.ServiceBackendPort{Number: 80}},
+							}},
+							{Path: "/api", PathType: &pathType, Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{Name: "api", Port: networkingv1.ServiceBackendPort{Name: "http"}},
+							}},
+						},
+					}},
+				}},
+			},
+		},
+		&networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", Labels: map[string]string{"app": "other"}}},
+	)
+	ctx := context.Background()
+
+	ingresses, err := client.ListIngresses(ctx, "default", "app=web")
+	if err != nil {
+		t.Fatalf("ListIngresses failed: %v", err)
+	}
+	if len(ingresses) != 1 {
+		t.Fatalf("expected 1 ingress, got %d", len(ingresses))
+	}
+
+	ingress := ingresses[0]
+	if ingress.Name != "web" || ingress.ClassName != "nginx" {
+		t.Errorf("unexpected ingress %+v", ingress)
+	}
+	if !reflect.DeepEqual(ingress.Hosts, []string{"web.example.com"}) || !reflect.DeepEqual(ingress.TLSHosts, []string{"web.example.com"}) {
+		t.Errorf("unexpected hosts %v and TLS hosts %v", ingress.Hosts, ingress.TLSHosts)
+	}
+	wantPaths := []domain.IngressPath{
+		{Host: "web.example.com", Path: "/", ServiceName: "web", ServicePort: "80"},
+		{Host: "web.example.com", Path: "/api", ServiceName: "api", ServicePort: "http"},
+	}
+	if !reflect.DeepEqual(ingress.Paths, wantPaths) {
+		t.Errorf("paths = %+v, want %+v", ingress.Paths, wantPaths)
+	}
+
+	if _, err := client.GetIngress(ctx, "default", "other"); err != nil {
+		t.Errorf("GetIngress failed: %v", err)
+	}
+	if _, err := client.GetIngress(ctx, "default", "missing"); err == nil {
+		t.Error("expected an error for a missing ingress")
+	}
+}
+
+func TestListJobsAndCronJobs(t *testing.T) {
+	completions := int32(3)
+	suspend := true
+	lastSchedule := metav1.NewTime(time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC))
+	client := newTestClient(
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"},
+			Spec:       batchv1.JobSpec{Completions: &completions},
+			Status:     batchv1.JobStatus{Active: 1, Succeeded: 2},
+		},
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+			Spec:       batchv1.CronJobSpec{Schedule: "0 3 * * *", Suspend: &suspend},
+			Status:     batchv1.CronJobStatus{LastScheduleTime: &lastSchedule},
+		},
+	)
+	ctx := context.Background()
+
+	jobs, err := client.ListJobs(ctx, "default", "")
+	if err != nil {
+		t.Fatalf("ListJobs failed: %v", err)
+	}
+	if len(jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(jobs))
+	}
+	job := jobs[0]
+	if job.Name != "migrate" || job.Completions != 3 || job.Parallelism != 1 || job.Active != 1 || job.Succeeded != 2 {
+		t.Errorf("unexpected job %+v", job)
+	}
+	if job.StartTime != nil || job.CompletionTime != nil {
+		t.Errorf("expected unset start and completion times, got %v and %v", job.StartTime, job.CompletionTime)
+	}
+
+	cronJob, err := client.GetCronJob(ctx, "default", "nightly")
+	if err != nil {
+		t.Fatalf("GetCronJob failed: %v", err)
+	}
+	if cronJob.Schedule != "0 3 * * *" || !cronJob.Suspend || cronJob.Active != 0 {
+		t.Errorf("unexpected cron job %+v", cronJob)
+	}
+	if cronJob.LastScheduleTime == nil || !cronJob.LastScheduleTime.Equal(lastSchedule.Time) {
+		t.Errorf("LastScheduleTime = %v, want %v", cronJob.LastScheduleTime, lastSchedule.Time)
+	}
+}
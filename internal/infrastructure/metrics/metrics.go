@@ -0,0 +1,149 @@
+// package metrics defines the Prometheus metrics exposed by the controller
+package metrics
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"k8s-controller/internal/domain"
+)
+
+var (
+	// EventsDropped counts resource events that were dropped after exhausting their retries
+	EventsDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "k8s_controller_events_dropped_total",
+			Help: "Number of resource events dropped after exceeding the maximum number of retries",
+		},
+		[]string{"kind", "type"},
+	)
+
+	// EventsBufferDropped counts resource events dropped because the event buffer was full
+	EventsBufferDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "k8s_controller_events_buffer_dropped_total",
+			Help: "Number of resource events dropped because the event buffer was full",
+		},
+		[]string{"kind", "type"},
+	)
+
+	// ListCacheHits counts direct API lists answered from the list cache
+	ListCacheHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "k8s_controller_list_cache_hits_total",
+			Help: "Number of direct API lists answered from the list cache",
+		},
+		[]string{"resource"},
+	)
+
+	// ListCacheMisses counts direct API lists sent to the API while the list cache is enabled
+	ListCacheMisses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "k8s_controller_list_cache_misses_total",
+			Help: "Number of direct API lists sent to the API while the list cache is enabled",
+		},
+		[]string{"resource"},
+	)
+
+	// ReconcileTotal counts reconciles by controller and outcome (success, error, requeue, permanent-error, not-found, skipped)
+	ReconcileTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "k8s_controller_reconcile_total",
+			Help: "Number of reconciles by controller and outcome",
+		},
+		[]string{"controller", "outcome"},
+	)
+
+	// ReconcileDuration observes how long each reconcile takes
+	ReconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "k8s_controller_reconcile_duration_seconds",
+			Help:    "Duration of reconciles in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"controller"},
+	)
+
+	// EventsProcessed counts resource events passed to the domain service by kind, type and namespace
+	EventsProcessed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "k8s_controller_events_processed_total",
+			Help: "Number of resource events processed by kind, type and namespace",
+		},
+		[]string{"kind", "type", "namespace"},
+	)
+
+	// EventProcessingDuration observes how long the domain service takes to handle each event
+	EventProcessingDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "k8s_controller_event_processing_duration_seconds",
+			Help:    "Duration of resource event processing in seconds by kind and namespace",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"kind", "namespace"},
+	)
+
+	// ReportDeployments reports the deployment counts of the last periodic report by state (total, ready, degraded)
+	ReportDeployments = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "k8s_controller_report_deployments",
+			Help: "Number of watched deployments by state in the last periodic report",
+		},
+		[]string{"state"},
+	)
+
+	// PolicyViolations reports the policy violations found by the last periodic report
+	PolicyViolations = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "k8s_controller_policy_violations",
+			Help: "Number of deployments violating each policy in the last periodic report",
+		},
+		[]string{"policy"},
+	)
+)
+
+// dropEventNamespace leaves the namespace label of the event metrics empty, which Prometheus
+// treats as an absent label
+var dropEventNamespace atomic.Bool
+
+func init() {
+	// Register with the controller-runtime registry so metrics are served by the manager and by Serve
+	ctrlmetrics.Registry.MustRegister(EventsDropped, EventsBufferDropped, ListCacheHits, ListCacheMisses, EventsProcessed, EventProcessingDuration, ReconcileTotal, ReconcileDuration,
+		ReportDeployments, PolicyViolations)
+}
+
+// RecordDeploymentReport sets the report gauges from a periodic deployment report.
+// Known policies without violations are reported as zero.
+func RecordDeploymentReport(report domain.DeploymentReport) {
+	ReportDeployments.WithLabelValues("total").Set(float64(report.Health.Total))
+	ReportDeployments.WithLabelValues("ready").Set(float64(report.Health.Ready))
+	ReportDeployments.WithLabelValues("degraded").Set(float64(report.Health.Degraded))
+
+	counts := map[string]int{domain.PolicyReplicaFloor: 0, domain.PolicyStuckRollout: 0}
+	for _, violation := range report.Violations {
+		counts[violation.Policy]++
+	}
+	for policy, count := range counts {
+		PolicyViolations.WithLabelValues(policy).Set(float64(count))
+	}
+}
+
+// SetEventNamespaceLabel controls whether the event metrics are labelled with the event's
+// namespace. Clusters with many short-lived namespaces can turn it off to bound cardinality.
+func SetEventNamespaceLabel(enabled bool) {
+	dropEventNamespace.Store(!enabled)
+}
+
+// RecordEventProcessed counts a processed resource event and observes how long it took
+func RecordEventProcessed(event domain.ResourceEvent, duration time.Duration) {
+	namespace := event.Resource.Namespace
+	if dropEventNamespace.Load() {
+		namespace = ""
+	}
+
+	EventsProcessed.WithLabelValues(event.Resource.Kind, string(event.Type), namespace).Inc()
+	EventProcessingDuration.WithLabelValues(event.Resource.Kind, namespace).Observe(duration.Seconds())
+}
@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"k8s-controller/internal/domain"
+)
+
+func TestRecordEventProcessedNamespaceLabel(t *testing.T) {
+	t.Cleanup(func() { SetEventNamespaceLabel(true) })
+	event := domain.ResourceEvent{
+		Type:     domain.ResourceEventUpdated,
+		Resource: domain.Resource{Kind: "Deployment", Name: "web", Namespace: "payments"},
+	}
+
+	RecordEventProcessed(event, time.Millisecond)
+	if got := testutil.ToFloat64(EventsProcessed.WithLabelValues("Deployment", "UPDATED", "payments")); got != 1 {
+		t.Errorf("expected one event labelled with its namespace, got %v", got)
+	}
+
+	SetEventNamespaceLabel(false)
+	RecordEventProcessed(event, time.Millisecond)
+	if got := testutil.ToFloat64(EventsProcessed.WithLabelValues("Deployment", "UPDATED", "")); got != 1 {
+		t.Errorf("expected one event without a namespace label, got %v", got)
+	}
+}
+
+func TestServeExposesEventMetrics(t *testing.T) {
+	srv, err := Serve("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+	t.Cleanup(func() { _ = srv.Close() })
+
+	RecordEventProcessed(domain.ResourceEvent{
+		Type:     domain.ResourceEventCreated,
+		Resource: domain.Resource{Kind: "Service", Name: "api", Namespace: "default"},
+	}, time.Millisecond)
+
+	body := scrape(t, srv.Addr)
+	for _, name := range []string{"k8s_controller_events_processed_total", "k8s_controller_event_processing_duration_seconds"} {
+		if !strings.Contains(body, name) {
+			t.Errorf("expected %s in the served metrics", name)
+		}
+	}
+}
+
+func TestServeFailsWhenAddressIsInUse(t *testing.T) {
+	srv, err := Serve("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+	t.Cleanup(func() { _ = srv.Close() })
+
+	if _, err := Serve(srv.Addr); err == nil {
+		t.Error("expected an error when the metrics address is already in use")
+	}
+}
+
+// scrape fetches /metrics from addr and returns the body
+func scrape(t *testing.T, addr string) string {
+	t.Helper()
+	resp, err := http.Get("http://" + addr + "/metrics")
+	if err != nil {
+		t.Fatalf("failed to scrape metrics: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read metrics: %v", err)
+	}
+	return string(body)
+}
+
+func TestServeExposesReportGauges(t *testing.T) {
+	srv, err := Serve("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+	t.Cleanup(func() { _ = srv.Close() })
+
+	RecordDeploymentReport(domain.DeploymentReport{
+		Health:     domain.DeploymentHealth{Total: 3, Ready: 2, Degraded: 1},
+		Violations: []domain.PolicyViolation{{Policy: domain.PolicyReplicaFloor}},
+	})
+
+	body := scrape(t, srv.Addr)
+	for _, line := range []string{
+		`k8s_controller_report_deployments{state="degraded"} 1`,
+		`k8s_controller_policy_violations{policy="` + domain.PolicyReplicaFloor + `"} 1`,
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("expected %q in the served metrics", line)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// package server provides HTTP server functionality using Fiber
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+// ConfigMapController handles config map-related HTTP endpoints
+type ConfigMapController struct {
+	client kubernetes.Client
+}
+
+// NewConfigMapController creates a new config map controller
+func NewConfigMapController(client kubernetes.Client) *ConfigMapController {
+	return &ConfigMapController{
+		client: client,
+	}
+}
+
+// ListConfigMaps handles requests to list config maps.
+// Data values are only included when include_values=true is set.
+func (c *ConfigMapController) ListConfigMaps(ctx *fiber.Ctx) error {
+	namespace := ctx.Query("namespace", "default")
+	includeValues := ctx.QueryBool("include_values", false)
+
+	reqCtx, cancel := context.WithTimeout(ctx.UserContext(), 10*time.Second)
+	defer cancel()
+
+	configMaps, err := c.client.ListConfigMaps(reqCtx, namespace)
+	if err != nil {
+		slog.Error("Failed to list config maps", "error", err, "namespace", namespace)
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Failed to list config maps",
+			"error":   err.Error(),
+		})
+	}
+
+	if !includeValues {
+		for i := range configMaps {
+			configMaps[i] = configMaps[i].WithoutValues()
+		}
+	}
+
+	return ctx.JSON(fiber.Map{
+		"status":     "success",
+		"namespace":  namespace,
+		"configmaps": configMaps,
+		"count":      len(configMaps),
+	})
+}
+
+// GetConfigMap handles requests to get a single config map.
+// Data values are only included when include_values=true is set.
+func (c *ConfigMapController) GetConfigMap(ctx *fiber.Ctx) error {
+	name := ctx.Params("name")
+	namespace := ctx.Query("namespace", "default")
+	includeValues := ctx.QueryBool("include_values", false)
+
+	reqCtx, cancel := context.WithTimeout(ctx.UserContext(), 10*time.Second)
+	defer cancel()
+
+	configMap, err := c.client.GetConfigMap(reqCtx, namespace, name)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if errors.IsNotFound(err) {
+			status = fiber.StatusNotFound
+		}
+		return ctx.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Failed to get config map",
+			"error":   err.Error(),
+		})
+	}
+
+	if !includeValues {
+		configMap = configMap.WithoutValues()
+	}
+
+	return ctx.JSON(fiber.Map{
+		"status":    "success",
+		"configmap": configMap,
+	})
+}
@@ -2,30 +2,45 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"k8s-controller/internal/domain"
 	"k8s-controller/internal/infrastructure/config"
 	"k8s-controller/internal/infrastructure/controller"
+	"k8s-controller/internal/infrastructure/kubernetes"
 )
 
+// healthCheckTimeout bounds how long a single readiness probe waits
+const healthCheckTimeout = 2 * time.Second
+
 // ControllerRuntimeServer extends the basic server with controller-runtime functionality
 type ControllerRuntimeServer struct {
 	*Server
-	controllerRuntime *controller.ControllerRuntime
-	resourceService   domain.ResourceService
+	controllerRuntime    *controller.ControllerRuntime
+	resourceService      domain.ResourceService
+	deploymentReconciler *controller.DeploymentReconciler
+	config               *config.Config
 }
 
 // NewControllerRuntimeServer creates a new server with controller-runtime capabilities
 func NewControllerRuntimeServer(port int, cfg *config.Config) (*ControllerRuntimeServer, error) {
 	// Create base server
-	baseServer := NewServer(port)
+	baseServer := NewServerWithConfig(cfg)
+	baseServer.port = port
 
 	// Create controller runtime
 	controllerRuntime, err := controller.NewControllerRuntime(cfg)
@@ -40,6 +55,7 @@ func NewControllerRuntimeServer(port int, cfg *config.Config) (*ControllerRuntim
 		Server:            baseServer,
 		controllerRuntime: controllerRuntime,
 		resourceService:   resourceService,
+		config:            cfg,
 	}
 
 	return server, nil
@@ -56,15 +72,60 @@ func (s *ControllerRuntimeServer) RegisterControllers(ctx context.Context) error
 		scheme,
 		s.resourceService,
 	)
+	deploymentReconciler.SetEventRecorder(s.controllerRuntime.GetManager().GetEventRecorderFor("k8s-controller"))
+	deploymentReconciler.SetNamespaces(s.config.ReconcileNamespaces)
+	deploymentReconciler.SetRequeueDelays(s.config.TransientRequeueDelay, s.config.PermanentRequeueDelay)
 
 	if err := s.controllerRuntime.RegisterDeploymentController(deploymentReconciler); err != nil {
 		return fmt.Errorf("failed to register deployment controller: %w", err)
 	}
+	s.deploymentReconciler = deploymentReconciler
+
+	// Register admission webhooks when the webhook server is enabled
+	if s.config.WebhookEnabled {
+		defaulter := controller.NewDeploymentDefaulter(s.config.WebhookDefaultLabels)
+		validator := controller.NewDeploymentValidator(int32(s.config.WebhookMinReplicas), s.config.WebhookRequiredLabels)
+		if err := s.controllerRuntime.RegisterDeploymentWebhooks(defaulter, validator); err != nil {
+			return fmt.Errorf("failed to register deployment webhooks: %w", err)
+		}
+	}
+
+	if err := s.registerHealthChecks(); err != nil {
+		return err
+	}
 
 	slog.Info("Controllers registered successfully")
 	return nil
 }
 
+// registerHealthChecks adds readiness checks so /readyz fails until the manager cache has
+// synced and while the API server is unreachable, and a liveness check so /healthz fails while
+// the deployment reconcile workers are stuck
+func (s *ControllerRuntimeServer) registerHealthChecks() error {
+	mgr := s.controllerRuntime.GetManager()
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create discovery client for health checks: %w", err)
+	}
+
+	if err := s.controllerRuntime.AddReadyCheck("informers-synced", controller.CacheSyncedCheck(mgr.GetCache(), healthCheckTimeout)); err != nil {
+		return err
+	}
+	if window := s.config.ReconcileStallTimeout; window > 0 {
+		check := controller.ReconcileProgressCheck(s.deploymentReconciler.Progress(), window)
+		if err := s.controllerRuntime.AddHealthCheck("reconcile-progress", check); err != nil {
+			return err
+		}
+	}
+	return s.controllerRuntime.AddReadyCheck("api-reachable", controller.APIReachableCheck(discoveryClient.RESTClient(), healthCheckTimeout))
+}
+
+// Endpoints returns the addresses of the manager's metrics and health probe endpoints
+func (s *ControllerRuntimeServer) Endpoints() (metrics, health string) {
+	return s.controllerRuntime.GetMetricsEndpoint(), s.controllerRuntime.GetHealthEndpoint()
+}
+
 // SetupControllerRuntimeRoutes adds controller-runtime specific API endpoints
 func (s *ControllerRuntimeServer) SetupControllerRuntimeRoutes() {
 	// API version prefix
@@ -83,6 +144,33 @@ func (s *ControllerRuntimeServer) SetupControllerRuntimeRoutes() {
 		})
 	})
 
+	// Add info endpoint reporting the effective runtime configuration
+	api.Get("/info", func(c *fiber.Ctx) error {
+		status := s.kubeClient.WatchStatus()
+
+		resyncPeriods := make(map[string]string, len(status.ResyncPeriods))
+		for resource, period := range status.ResyncPeriods {
+			resyncPeriods[resource] = period.String()
+		}
+
+		return c.JSON(fiber.Map{
+			"namespaces":     status.Namespaces,
+			"resources":      status.Resources,
+			"resync_period":  status.ResyncPeriod.String(),
+			"resync_periods": resyncPeriods,
+			"cache_synced":   status.CacheSynced,
+			"cluster_scoped": status.ClusterScoped,
+			// Informers stopped because the service account may not list or watch the resource
+			"degraded_informers": status.DegradedInformers,
+			"leader_election": fiber.Map{
+				"enabled":   s.config.EnableLeaderElection,
+				"id":        s.config.LeaderElectionID,
+				"namespace": s.config.LeaderElectionNamespace,
+				"leader":    s.controllerRuntime.IsLeader(),
+			},
+		})
+	})
+
 	// Add controller-runtime status endpoint
 	api.Get("/controller", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
@@ -99,7 +187,25 @@ func (s *ControllerRuntimeServer) SetupControllerRuntimeRoutes() {
 		// Get namespace from query param, default to "default"
 		namespace := c.Query("namespace", "default")
 
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		// Validate the sort key before listing
+		sortKey := c.Query("sort")
+		if err := domain.ValidateDeploymentSortKey(sortKey); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid sort key",
+				"details": err.Error(),
+			})
+		}
+
+		// Validate the status filter before listing
+		statusFilter := c.Query("status")
+		if err := domain.ValidateDeploymentStatusFilter(statusFilter); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid status filter",
+				"details": err.Error(),
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 		defer cancel()
 
 		// Use controller-runtime client to list deployments
@@ -116,18 +222,11 @@ func (s *ControllerRuntimeServer) SetupControllerRuntimeRoutes() {
 
 		// Convert to domain models
 		deployments := make([]domain.Deployment, 0, len(deploymentList.Items))
-		for _, d := range deploymentList.Items {
-			deployments = append(deployments, domain.Deployment{
-				Name:              d.Name,
-				Namespace:         d.Namespace,
-				Replicas:          *d.Spec.Replicas,
-				ReadyReplicas:     d.Status.ReadyReplicas,
-				AvailableReplicas: d.Status.AvailableReplicas,
-				UpdatedReplicas:   d.Status.UpdatedReplicas,
-				Labels:            d.Labels,
-				CreationTimestamp: d.CreationTimestamp.String(),
-			})
+		for i := range deploymentList.Items {
+			deployments = append(deployments, kubernetes.ToDomainDeployment(&deploymentList.Items[i]))
 		}
+		deployments, _ = domain.FilterDeploymentsByStatus(deployments, statusFilter)
+		_ = domain.SortDeployments(deployments, sortKey)
 
 		return c.JSON(fiber.Map{
 			"deployments": deployments,
@@ -136,12 +235,86 @@ func (s *ControllerRuntimeServer) SetupControllerRuntimeRoutes() {
 		})
 	})
 
-	// GET /api/v1/deployments/:name
+	// DELETE /api/v1/deployments?selector=app=old&confirm=true
+	deploymentAPI.Delete("/", func(c *fiber.Ctx) error {
+		namespace := c.Query("namespace", "default")
+		selectorParam := c.Query("selector")
+
+		// Require explicit confirmation to prevent accidental bulk deletion
+		if !c.QueryBool("confirm", false) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Bulk delete requires confirm=true",
+			})
+		}
+
+		selector, err := labels.Parse(selectorParam)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid label selector",
+				"details": err.Error(),
+			})
+		}
+
+		// A missing or blank selector such as selector=%20 parses to Everything
+		if selector.Empty() {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "A label selector is required for bulk delete",
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
+		defer cancel()
+
+		var deploymentList appsv1.DeploymentList
+		if err := s.controllerRuntime.GetClient().List(ctx, &deploymentList, &client.ListOptions{
+			Namespace:     namespace,
+			LabelSelector: selector,
+		}); err != nil {
+			slog.Error("Failed to list deployments for bulk delete", "selector", selectorParam, "error", err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   "Failed to list deployments",
+				"details": err.Error(),
+			})
+		}
+
+		// Delete each match and record the outcome per object
+		results := make([]fiber.Map, 0, len(deploymentList.Items))
+		deleted := 0
+		for i := range deploymentList.Items {
+			deployment := &deploymentList.Items[i]
+			result := fiber.Map{
+				"name":      deployment.Name,
+				"namespace": deployment.Namespace,
+				"deleted":   true,
+			}
+
+			if err := s.controllerRuntime.GetClient().Delete(ctx, deployment); err != nil {
+				slog.Error("Failed to delete deployment", "name", deployment.Name, "namespace", deployment.Namespace, "error", err)
+				result["deleted"] = false
+				result["error"] = err.Error()
+			} else {
+				slog.Info("Deleted deployment", "name", deployment.Name, "namespace", deployment.Namespace, "selector", selectorParam)
+				deleted++
+			}
+
+			results = append(results, result)
+		}
+
+		return c.JSON(fiber.Map{
+			"namespace": namespace,
+			"selector":  selectorParam,
+			"matched":   len(deploymentList.Items),
+			"deleted":   deleted,
+			"results":   results,
+		})
+	})
+
+	// GET /api/v1/deployments/:name, with ?raw=true for the full Kubernetes object
 	deploymentAPI.Get("/:name", func(c *fiber.Ctx) error {
 		name := c.Params("name")
 		namespace := c.Query("namespace", "default")
 
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
 		defer cancel()
 
 		// Use controller-runtime client to get deployment
@@ -157,21 +330,97 @@ func (s *ControllerRuntimeServer) SetupControllerRuntimeRoutes() {
 			})
 		}
 
-		// Convert to domain model
-		deploymentModel := domain.Deployment{
-			Name:              deployment.Name,
-			Namespace:         deployment.Namespace,
-			Replicas:          *deployment.Spec.Replicas,
-			ReadyReplicas:     deployment.Status.ReadyReplicas,
-			AvailableReplicas: deployment.Status.AvailableReplicas,
-			UpdatedReplicas:   deployment.Status.UpdatedReplicas,
-			Labels:            deployment.Labels,
-			CreationTimestamp: deployment.CreationTimestamp.String(),
+		// Return every field of the object for clients that need more than the domain model
+		if c.QueryBool("raw") {
+			// Objects read from the cache have no type meta, so set it for a self-describing response
+			deployment.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+			return c.JSON(&deployment)
 		}
 
+		// Convert to domain model
+		deploymentModel := kubernetes.ToDomainDeployment(&deployment)
+
 		return c.JSON(deploymentModel)
 	})
 
+	// PATCH /api/v1/deployments/:name with a JSON merge patch body
+	deploymentAPI.Patch("/:name", func(c *fiber.Ctx) error {
+		name := c.Params("name")
+		namespace := c.Query("namespace", "default")
+
+		if mediaType := strings.TrimSpace(strings.Split(c.Get(fiber.HeaderContentType), ";")[0]); mediaType != string(types.MergePatchType) {
+			return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{
+				"error": fmt.Sprintf("Content-Type must be %s", types.MergePatchType),
+			})
+		}
+
+		body := c.Body()
+		if !json.Valid(body) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Request body is not valid JSON",
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), 10*time.Second)
+		defer cancel()
+
+		// Patch fills the deployment with the object returned by the API server
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		}
+		if err := s.controllerRuntime.GetClient().Patch(ctx, deployment, client.RawPatch(types.MergePatchType, body)); err != nil {
+			slog.Error("Failed to patch deployment", "name", name, "namespace", namespace, "error", err)
+			status := fiber.StatusInternalServerError
+			switch {
+			case apierrors.IsNotFound(err):
+				status = fiber.StatusNotFound
+			case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+				status = fiber.StatusUnprocessableEntity
+			}
+			return c.Status(status).JSON(fiber.Map{
+				"error":   "Failed to patch deployment",
+				"details": err.Error(),
+			})
+		}
+
+		slog.Info("Patched deployment", "name", name, "namespace", namespace)
+		return c.JSON(kubernetes.ToDomainDeployment(deployment))
+	})
+
+	// POST /api/v1/deployments/:name/reconcile
+	deploymentAPI.Post("/:name/reconcile", func(c *fiber.Ctx) error {
+		name := c.Params("name")
+		namespace := c.Query("namespace", "default")
+
+		if s.deploymentReconciler == nil {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": "Deployment reconciler is not registered",
+			})
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), 30*time.Second)
+		defer cancel()
+
+		// Invoke the reconciler directly for the requested object
+		slog.Info("Reconciling deployment on demand", "name", name, "namespace", namespace)
+		result, err := s.deploymentReconciler.Reconcile(ctx, ctrl.Request{
+			NamespacedName: types.NamespacedName{Namespace: namespace, Name: name},
+		})
+
+		response := fiber.Map{
+			"name":          name,
+			"namespace":     namespace,
+			"requeue":       result.Requeue || result.RequeueAfter > 0,
+			"requeue_after": result.RequeueAfter.String(),
+		}
+		if err != nil {
+			response["error"] = err.Error()
+			return c.Status(fiber.StatusInternalServerError).JSON(response)
+		}
+
+		return c.JSON(response)
+	})
+
 	// Status routes
 	api.Get("/status", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
@@ -185,18 +434,23 @@ func (s *ControllerRuntimeServer) SetupControllerRuntimeRoutes() {
 
 // Start begins the server and controller manager
 func (s *ControllerRuntimeServer) Start() error {
-	// Create a background context for controller runtime
-	ctx := context.Background()
+	return s.StartWithContext(context.Background())
+}
 
-	// Start controller manager in a goroutine
+// StartWithContext begins the server and controller manager and stops both
+// when the context is cancelled
+func (s *ControllerRuntimeServer) StartWithContext(ctx context.Context) error {
+	// Start controller manager in a goroutine, it stops when ctx is cancelled
 	go func() {
 		if err := s.controllerRuntime.Start(ctx); err != nil {
 			slog.Error("Error starting controller manager", "error", err)
 		}
 	}()
 
-	// Start the fiber server
-	return s.Server.Start()
+	// Start the fiber server and stop the manager once it returns
+	err := s.Server.StartWithContext(ctx)
+	s.controllerRuntime.Stop()
+	return err
 }
 
 // Shutdown gracefully stops both the server and controller manager
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"k8s-controller/internal/infrastructure/config"
+	"k8s-controller/internal/infrastructure/controller"
+)
+
+func TestBulkDeleteRejectsBlankSelector(t *testing.T) {
+	cfg := config.Default()
+	s := &ControllerRuntimeServer{
+		Server:            NewServerWithConfig(cfg),
+		controllerRuntime: &controller.ControllerRuntime{},
+		config:            cfg,
+	}
+	s.SetupControllerRuntimeRoutes()
+
+	for _, selector := range []string{"", "%20", "%20%20%09"} {
+		req := httptest.NewRequest("DELETE", "/api/v1/deployments?confirm=true&selector="+selector, nil)
+		resp, err := s.app.Test(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("selector %q: expected status 400, got %d", selector, resp.StatusCode)
+		}
+	}
+}
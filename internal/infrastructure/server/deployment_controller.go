@@ -3,12 +3,17 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 
 	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 
 	"k8s-controller/internal/domain"
@@ -32,17 +37,65 @@ func (c *DeploymentController) ListDeployments(ctx *fiber.Ctx) error {
 	// Get namespace from query param, default to "default"
 	namespace := ctx.Query("namespace", "default")
 
+	// Validate the sort key before listing
+	sortKey := ctx.Query("sort")
+	if err := domain.ValidateDeploymentSortKey(sortKey); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid sort key",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate the status filter before listing
+	statusFilter := ctx.Query("status")
+	if err := domain.ValidateDeploymentStatusFilter(statusFilter); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid status filter",
+			"error":   err.Error(),
+		})
+	}
+
+	// Validate the label selector before listing
+	selector := ctx.Query("selector")
+	if _, err := labels.Parse(selector); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid label selector",
+			"error":   err.Error(),
+		})
+	}
+
 	// Create a context with timeout
-	reqCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	reqCtx, cancel := context.WithTimeout(ctx.UserContext(), 10*time.Second)
 	defer cancel()
 
 	var deployments []domain.Deployment
 	var source string
+	// skipped counts cached objects that were not deployments
+	var skipped int
 
 	// Try to get the informer to use its store directly
 	informer, err := c.client.GetDeploymentInformer(namespace)
+	if err == nil && !informer.HasSynced() {
+		// An unsynced cache may be empty, so don't report it as the deployment list
+		err = fmt.Errorf("deployment cache for namespace %s has not synced", namespace)
+	}
 
-	if err != nil {
+	if selector != "" {
+		// The client answers selectors from a label index when one exists, then the cache, then the API
+		deployments, err = c.client.ListDeploymentsBySelector(reqCtx, namespace, selector)
+		if err != nil {
+			slog.Error("Failed to list deployments", "error", err, "namespace", namespace, "selector", selector)
+			return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Failed to list deployments",
+				"error":   err.Error(),
+			})
+		}
+		source = "selector"
+	} else if err != nil {
 		slog.Warn("Could not get deployment informer, falling back to client",
 			"namespace", namespace, "error", err)
 
@@ -59,7 +112,7 @@ func (c *DeploymentController) ListDeployments(ctx *fiber.Ctx) error {
 		source = "api"
 	} else {
 		// Get items directly from the store (cached data)
-		storeDeployments, storeErr := c.getDeploymentsFromStore(informer.GetStore(), namespace)
+		storeDeployments, storeSkipped, storeErr := c.getDeploymentsFromStore(informer.GetStore(), namespace)
 		if storeErr != nil {
 			slog.Error("Failed to get deployments from informer store", "error", storeErr, "namespace", namespace)
 
@@ -77,10 +130,14 @@ func (c *DeploymentController) ListDeployments(ctx *fiber.Ctx) error {
 			source = "api-fallback"
 		} else {
 			deployments = storeDeployments
+			skipped = storeSkipped
 			source = "informer-cache"
 		}
 	}
 
+	deployments, _ = domain.FilterDeploymentsByStatus(deployments, statusFilter)
+	_ = domain.SortDeployments(deployments, sortKey)
+
 	// Return successful response with deployments
 	return ctx.JSON(fiber.Map{
 		"status":      "success",
@@ -88,19 +145,163 @@ func (c *DeploymentController) ListDeployments(ctx *fiber.Ctx) error {
 		"deployments": deployments,
 		"count":       len(deployments),
 		"source":      source,
+		"skipped":     skipped,
+	})
+}
+
+// GetDeploymentHistory handles requests for a deployment's rollout history, newest revision first
+func (c *DeploymentController) GetDeploymentHistory(ctx *fiber.Ctx) error {
+	name := ctx.Params("name")
+	namespace := ctx.Query("namespace", "default")
+
+	reqCtx, cancel := context.WithTimeout(ctx.UserContext(), 10*time.Second)
+	defer cancel()
+
+	revisions, err := c.client.ListDeploymentHistory(reqCtx, namespace, name)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if apierrors.IsNotFound(err) {
+			status = fiber.StatusNotFound
+		}
+		return ctx.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Failed to get deployment history",
+			"error":   err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"status":     "success",
+		"namespace":  namespace,
+		"deployment": name,
+		"revisions":  revisions,
+		"count":      len(revisions),
+	})
+}
+
+// RollbackDeployment handles requests to roll a deployment back to a revision from its history.
+// Without a revision it rolls back to the previous one.
+func (c *DeploymentController) RollbackDeployment(ctx *fiber.Ctx) error {
+	name := ctx.Params("name")
+	namespace := ctx.Query("namespace", "default")
+
+	var revision int64
+	if value := ctx.Query("revision"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 1 {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Invalid revision",
+				"error":   fmt.Sprintf("revision must be a positive integer, got %q", value),
+			})
+		}
+		revision = parsed
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx.UserContext(), 10*time.Second)
+	defer cancel()
+
+	target, err := c.client.RollbackDeployment(reqCtx, namespace, name, revision)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		switch {
+		case errors.Is(err, kubernetes.ErrRevisionNotFound):
+			status = fiber.StatusBadRequest
+		case apierrors.IsNotFound(err):
+			status = fiber.StatusNotFound
+		}
+		return ctx.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Failed to roll back deployment",
+			"error":   err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"status":     "success",
+		"message":    fmt.Sprintf("Rolled back deployment %s to revision %d", name, target.Revision),
+		"namespace":  namespace,
+		"deployment": name,
+		"revision":   target,
+	})
+}
+
+// applyDeploymentRequest is the body of an apply request
+type applyDeploymentRequest struct {
+	Image    string            `json:"image"`
+	Replicas *int32            `json:"replicas"`
+	Labels   map[string]string `json:"labels"`
+}
+
+// ApplyDeployment handles requests to create a deployment or update its image, replicas and
+// labels. Invalid deployments are rejected with 400 and the errors of each field.
+func (c *DeploymentController) ApplyDeployment(ctx *fiber.Ctx) error {
+	name := ctx.Params("name")
+	namespace := ctx.Query("namespace", "default")
+
+	var body applyDeploymentRequest
+	if err := ctx.BodyParser(&body); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid deployment",
+			"error":   err.Error(),
+		})
+	}
+
+	resource := domain.Resource{
+		Kind:      "Deployment",
+		Name:      name,
+		Namespace: namespace,
+		Labels:    body.Labels,
+		Data:      map[string]interface{}{"image": body.Image},
+	}
+	if body.Replicas != nil {
+		resource.Data["replicas"] = *body.Replicas
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx.UserContext(), 10*time.Second)
+	defer cancel()
+
+	if err := c.client.ApplyResource(reqCtx, resource); err != nil {
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  "error",
+				"message": "Invalid deployment",
+				"errors":  validationErr.Errors,
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Failed to apply deployment",
+			"error":   err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"status":     "success",
+		"message":    fmt.Sprintf("Applied deployment %s", name),
+		"namespace":  namespace,
+		"deployment": name,
 	})
 }
 
-// getDeploymentsFromStore converts informer store items to domain deployments
-func (c *DeploymentController) getDeploymentsFromStore(store cache.Store, namespace string) ([]domain.Deployment, error) {
+// getDeploymentsFromStore converts informer store items to domain deployments.
+// It also returns how many items were skipped because they were not deployments,
+// which points to a store holding the wrong type.
+func (c *DeploymentController) getDeploymentsFromStore(store cache.Store, namespace string) ([]domain.Deployment, int, error) {
 	// Get all items from the store
 	objs := store.List()
 
 	var deployments []domain.Deployment
+	skipped := 0
 	for _, obj := range objs {
+		// Unwrap tombstones so deletions during a list are not reported as type mismatches
+		obj = kubernetes.UnwrapTombstone(obj)
 		dep, ok := obj.(*appsv1.Deployment)
 		if !ok {
-			slog.Error("Failed to convert object to Deployment")
+			skipped++
+			slog.Warn("Skipping informer store object that is not a Deployment", "type", fmt.Sprintf("%T", obj))
 			continue
 		}
 
@@ -110,18 +311,12 @@ func (c *DeploymentController) getDeploymentsFromStore(store cache.Store, namesp
 		}
 
 		// Convert to domain model
-		deployment := domain.Deployment{
-			Name:              dep.Name,
-			Namespace:         dep.Namespace,
-			ReadyReplicas:     dep.Status.ReadyReplicas,
-			UpdatedReplicas:   dep.Status.UpdatedReplicas,
-			AvailableReplicas: dep.Status.AvailableReplicas,
-			Replicas:          *dep.Spec.Replicas,
-			Labels:            dep.Labels,
-			CreationTimestamp: dep.CreationTimestamp.Format("2006-01-02 15:04:05"),
-		}
-		deployments = append(deployments, deployment)
+		deployments = append(deployments, kubernetes.ToDomainDeployment(dep))
+	}
+
+	if skipped > 0 {
+		slog.Warn("Informer store contained objects that are not Deployments", "skipped", skipped, "namespace", namespace)
 	}
 
-	return deployments, nil
+	return deployments, skipped, nil
 }
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+func TestGetDeploymentsFromStoreUnwrapsTombstones(t *testing.T) {
+	store := cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+	objects := []interface{}{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		cache.DeletedFinalStateUnknown{
+			Key: "default/api",
+			Obj: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}},
+		},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "stray", Namespace: "default"}},
+	}
+	for _, obj := range objects {
+		if err := store.Add(obj); err != nil {
+			t.Fatalf("failed to add %T to store: %v", obj, err)
+		}
+	}
+
+	deployments, skipped, err := (&DeploymentController{}).getDeploymentsFromStore(store, "default")
+	if err != nil {
+		t.Fatalf("getDeploymentsFromStore failed: %v", err)
+	}
+	if len(deployments) != 2 {
+		t.Errorf("expected web and api deployments, got %+v", deployments)
+	}
+	if skipped != 1 {
+		t.Errorf("expected only the pod to be skipped, got %d", skipped)
+	}
+}
+
+func TestApplyDeploymentReportsFieldErrors(t *testing.T) {
+	client := kubernetes.NewClient(kubernetes.WithClientset(fake.NewSimpleClientset()))
+	app := fiber.New()
+	app.Put("/deployments/:name", NewDeploymentController(client).ApplyDeployment)
+
+	put := func(body string) (int, map[string]interface{}) {
+		t.Helper()
+		req := httptest.NewRequest("PUT", "/deployments/web?namespace=default", strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		var payload map[string]interface{}
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp.StatusCode, payload
+	}
+
+	status, payload := put(`{"image":" "}`)
+	if status != fiber.StatusBadRequest {
+		t.Fatalf("expected 400 for a deployment without an image, got %d", status)
+	}
+	fieldErrors, _ := payload["errors"].([]interface{})
+	if len(fieldErrors) != 1 || fieldErrors[0].(map[string]interface{})["field"] != "image" {
+		t.Errorf("expected a single image field error, got %v", payload["errors"])
+	}
+
+	if status, payload := put(`{"image":"web:1","replicas":2}`); status != fiber.StatusOK {
+		t.Fatalf("expected 200 for a valid deployment, got %d: %v", status, payload)
+	}
+	deployment, err := client.GetDeployment(context.Background(), "default", "web")
+	if err != nil {
+		t.Fatalf("GetDeployment failed: %v", err)
+	}
+	if deployment.Replicas != 2 {
+		t.Errorf("expected the applied deployment to have 2 replicas, got %d", deployment.Replicas)
+	}
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"k8s-controller/internal/domain"
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+// EventController handles the resource event debug endpoints
+type EventController struct {
+	client kubernetes.Client
+}
+
+// NewEventController creates a new event controller
+func NewEventController(client kubernetes.Client) *EventController {
+	return &EventController{
+		client: client,
+	}
+}
+
+// ReplayEvent handles requests to feed a resource event into the registered event handler, as if
+// an informer had seen it, and reports the handler's error
+func (c *EventController) ReplayEvent(ctx *fiber.Ctx) error {
+	var event domain.ResourceEvent
+	if err := ctx.BodyParser(&event); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid resource event",
+			"error":   err.Error(),
+		})
+	}
+
+	eventType, err := domain.ParseEventType(string(event.Type))
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid resource event",
+			"error":   err.Error(),
+		})
+	}
+	event.Type = eventType
+	if event.Resource.Kind == "" || event.Resource.Name == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Invalid resource event",
+			"error":   "resource kind and name are required",
+		})
+	}
+
+	if err := c.client.ReplayEvent(ctx.UserContext(), event); err != nil {
+		slog.Warn("Replayed event failed", "error", err, "kind", event.Resource.Kind, "name", event.Resource.Name)
+		return ctx.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Event handler returned an error",
+			"error":   err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"status": "success",
+		"event":  event,
+	})
+}
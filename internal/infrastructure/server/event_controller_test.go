@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s-controller/internal/domain"
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+// replayHandler records replayed events and fails with err
+type replayHandler struct {
+	events []domain.ResourceEvent
+	err    error
+}
+
+func (h *replayHandler) HandleEvent(ctx context.Context, event domain.ResourceEvent) error {
+	h.events = append(h.events, event)
+	return h.err
+}
+
+func TestReplayEvent(t *testing.T) {
+	client := kubernetes.NewClient(kubernetes.WithClientset(fake.NewSimpleClientset()))
+	handler := &replayHandler{}
+	client.SetEventHandler(handler)
+
+	app := fiber.New()
+	app.Post("/events/replay", NewEventController(client).ReplayEvent)
+
+	post := func(body string) int {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/events/replay", strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		return resp.StatusCode
+	}
+
+	event := `{"type":"updated","resource":{"kind":"Deployment","name":"web","namespace":"default","labels":{"app":"web"}}}`
+	if status := post(event); status != fiber.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if len(handler.events) != 1 {
+		t.Fatalf("expected the handler to receive 1 event, got %d", len(handler.events))
+	}
+	got := handler.events[0]
+	if got.Type != domain.ResourceEventUpdated || got.Resource.Name != "web" || got.Resource.Labels["app"] != "web" {
+		t.Errorf("unexpected replayed event %+v", got)
+	}
+
+	// The handler's error is returned to the caller
+	handler.err = errors.New("replica floor violated")
+	if status := post(event); status != fiber.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for a failing handler, got %d", status)
+	}
+
+	// Invalid events never reach the handler
+	for _, body := range []string{`{"type":"PATCHED","resource":{"kind":"Deployment","name":"web"}}`, `{"type":"CREATED"}`, `not json`} {
+		if status := post(body); status != fiber.StatusBadRequest {
+			t.Errorf("expected 400 for %s, got %d", body, status)
+		}
+	}
+	if len(handler.events) != 2 {
+		t.Errorf("expected invalid events to be rejected, handler got %d events", len(handler.events))
+	}
+}
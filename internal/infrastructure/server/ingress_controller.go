@@ -0,0 +1,78 @@
+// package server provides HTTP server functionality using Fiber
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+// IngressController handles ingress-related HTTP endpoints
+type IngressController struct {
+	client kubernetes.Client
+}
+
+// NewIngressController creates a new ingress controller
+func NewIngressController(client kubernetes.Client) *IngressController {
+	return &IngressController{
+		client: client,
+	}
+}
+
+// ListIngresses handles requests to list ingresses, optionally filtered by a label selector
+func (c *IngressController) ListIngresses(ctx *fiber.Ctx) error {
+	namespace := ctx.Query("namespace", "default")
+	selector := ctx.Query("selector")
+
+	reqCtx, cancel := context.WithTimeout(ctx.UserContext(), 10*time.Second)
+	defer cancel()
+
+	ingresses, err := c.client.ListIngresses(reqCtx, namespace, selector)
+	if err != nil {
+		slog.Error("Failed to list ingresses", "error", err, "namespace", namespace)
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Failed to list ingresses",
+			"error":   err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"status":    "success",
+		"namespace": namespace,
+		"ingresses": ingresses,
+		"count":     len(ingresses),
+	})
+}
+
+// GetIngress handles requests to get a single ingress
+func (c *IngressController) GetIngress(ctx *fiber.Ctx) error {
+	name := ctx.Params("name")
+	namespace := ctx.Query("namespace", "default")
+
+	reqCtx, cancel := context.WithTimeout(ctx.UserContext(), 10*time.Second)
+	defer cancel()
+
+	ingress, err := c.client.GetIngress(reqCtx, namespace, name)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if errors.IsNotFound(err) {
+			status = fiber.StatusNotFound
+		}
+		return ctx.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Failed to get ingress",
+			"error":   err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"status":  "success",
+		"ingress": ingress,
+	})
+}
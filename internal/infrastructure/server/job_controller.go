@@ -0,0 +1,131 @@
+// package server provides HTTP server functionality using Fiber
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+// JobController handles job and cron job HTTP endpoints
+type JobController struct {
+	client kubernetes.Client
+}
+
+// NewJobController creates a new job controller
+func NewJobController(client kubernetes.Client) *JobController {
+	return &JobController{
+		client: client,
+	}
+}
+
+// ListJobs handles requests to list jobs, optionally filtered by a label selector
+func (c *JobController) ListJobs(ctx *fiber.Ctx) error {
+	namespace := ctx.Query("namespace", "default")
+	selector := ctx.Query("selector")
+
+	reqCtx, cancel := context.WithTimeout(ctx.UserContext(), 10*time.Second)
+	defer cancel()
+
+	jobs, err := c.client.ListJobs(reqCtx, namespace, selector)
+	if err != nil {
+		slog.Error("Failed to list jobs", "error", err, "namespace", namespace)
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Failed to list jobs",
+			"error":   err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"status":    "success",
+		"namespace": namespace,
+		"jobs":      jobs,
+		"count":     len(jobs),
+	})
+}
+
+// GetJob handles requests to get a single job
+func (c *JobController) GetJob(ctx *fiber.Ctx) error {
+	name := ctx.Params("name")
+	namespace := ctx.Query("namespace", "default")
+
+	reqCtx, cancel := context.WithTimeout(ctx.UserContext(), 10*time.Second)
+	defer cancel()
+
+	job, err := c.client.GetJob(reqCtx, namespace, name)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if errors.IsNotFound(err) {
+			status = fiber.StatusNotFound
+		}
+		return ctx.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Failed to get job",
+			"error":   err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"status": "success",
+		"job":    job,
+	})
+}
+
+// ListCronJobs handles requests to list cron jobs, optionally filtered by a label selector
+func (c *JobController) ListCronJobs(ctx *fiber.Ctx) error {
+	namespace := ctx.Query("namespace", "default")
+	selector := ctx.Query("selector")
+
+	reqCtx, cancel := context.WithTimeout(ctx.UserContext(), 10*time.Second)
+	defer cancel()
+
+	cronJobs, err := c.client.ListCronJobs(reqCtx, namespace, selector)
+	if err != nil {
+		slog.Error("Failed to list cron jobs", "error", err, "namespace", namespace)
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Failed to list cron jobs",
+			"error":   err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"status":    "success",
+		"namespace": namespace,
+		"cronjobs":  cronJobs,
+		"count":     len(cronJobs),
+	})
+}
+
+// GetCronJob handles requests to get a single cron job
+func (c *JobController) GetCronJob(ctx *fiber.Ctx) error {
+	name := ctx.Params("name")
+	namespace := ctx.Query("namespace", "default")
+
+	reqCtx, cancel := context.WithTimeout(ctx.UserContext(), 10*time.Second)
+	defer cancel()
+
+	cronJob, err := c.client.GetCronJob(reqCtx, namespace, name)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if errors.IsNotFound(err) {
+			status = fiber.StatusNotFound
+		}
+		return ctx.Status(status).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Failed to get cron job",
+			"error":   err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"status":  "success",
+		"cronjob": cronJob,
+	})
+}
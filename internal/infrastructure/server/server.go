@@ -3,14 +3,21 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 
+	"k8s-controller/internal/app/handlers"
+	"k8s-controller/internal/domain"
+	"k8s-controller/internal/infrastructure/config"
 	"k8s-controller/internal/infrastructure/kubernetes"
 )
 
@@ -20,15 +27,33 @@ type Server struct {
 	port           int
 	kubeClient     kubernetes.Client
 	deploymentCtrl *DeploymentController
+	configMapCtrl  *ConfigMapController
+	ingressCtrl    *IngressController
+	jobCtrl        *JobController
+	summaryCtrl    *SummaryController
+	eventCtrl      *EventController
+	// eventReplay serves the event replay debug endpoint
+	eventReplay bool
+	// tlsCertFile and tlsKeyFile switch the server to HTTPS when both are set
+	tlsCertFile string
+	tlsKeyFile  string
+	// tlsClientCA requires client certificates signed by the CAs in the file when set
+	tlsClientCA string
+	certs       *certReloader
 }
 
-// NewServer creates a new HTTP server instance
-func NewServer(port int) *Server {
+// NewServer creates a new HTTP server instance whose Kubernetes client is created with opts
+func NewServer(port int, opts ...kubernetes.ClientOption) *Server {
 	// Create Kubernetes client
-	kubeClient := kubernetes.NewClient()
+	kubeClient := kubernetes.NewClient(opts...)
 
 	// Initialize controllers
 	deploymentCtrl := NewDeploymentController(kubeClient)
+	configMapCtrl := NewConfigMapController(kubeClient)
+	ingressCtrl := NewIngressController(kubeClient)
+	jobCtrl := NewJobController(kubeClient)
+	summaryCtrl := NewSummaryController(kubeClient)
+	eventCtrl := NewEventController(kubeClient)
 
 	app := fiber.New(fiber.Config{
 		AppName:               "K8s Controller API",
@@ -37,8 +62,11 @@ func NewServer(port int) *Server {
 
 	// Add middleware
 	app.Use(recover.New())
+	app.Use(requestid.New())
+	app.Use(propagateRequestID)
+	app.Use(bypassListCache)
 	app.Use(logger.New(logger.Config{
-		Format: "[${time}] ${status} - ${method} ${path} (${latency})\n",
+		Format: "[${time}] ${locals:requestid} ${status} - ${method} ${path} (${latency})\n",
 	}))
 
 	return &Server{
@@ -46,9 +74,102 @@ func NewServer(port int) *Server {
 		port:           port,
 		kubeClient:     kubeClient,
 		deploymentCtrl: deploymentCtrl,
+		configMapCtrl:  configMapCtrl,
+		ingressCtrl:    ingressCtrl,
+		jobCtrl:        jobCtrl,
+		summaryCtrl:    summaryCtrl,
+		eventCtrl:      eventCtrl,
 	}
 }
 
+// propagateRequestID stores the request ID set by the requestid middleware in the request's user
+// context, so Kubernetes client calls made with it log the same ID
+func propagateRequestID(c *fiber.Ctx) error {
+	if id, ok := c.Locals("requestid").(string); ok && id != "" {
+		c.SetUserContext(kubernetes.WithRequestID(c.UserContext(), id))
+	}
+	return c.Next()
+}
+
+// bypassListCache makes Kubernetes client calls of requests with ?cache=false skip the list
+// cache and read from the API
+func bypassListCache(c *fiber.Ctx) error {
+	if c.Query("cache") == "false" {
+		c.SetUserContext(kubernetes.WithoutListCache(c.UserContext()))
+	}
+	return c.Next()
+}
+
+// NewServerWithConfig creates a new HTTP server whose Kubernetes client uses the given configuration
+func NewServerWithConfig(cfg *config.Config, opts ...kubernetes.ClientOption) *Server {
+	s := NewServer(cfg.ServerPort, opts...)
+	s.kubeClient.SetResyncPeriods(cfg.ResyncPeriod, cfg.ResyncPeriods)
+	s.kubeClient.SetNamespaces(cfg.ResourceNamespaces)
+	s.kubeClient.SetDiscoverNamespaces(cfg.DiscoverNamespaces)
+	s.kubeClient.SetInformerScope(cfg.ClusterScopeThreshold, cfg.PerNamespaceInformers)
+	s.kubeClient.SetIndexLabels(cfg.IndexLabels)
+	s.kubeClient.SetWatchSelector(cfg.WatchSelector)
+	s.kubeClient.SetResourceSelectors(cfg.WatchSelectors)
+	s.kubeClient.SetListCacheTTL(cfg.ListCacheTTL)
+	s.kubeClient.SetImpersonation(cfg.ImpersonateUser, cfg.ImpersonateGroups)
+	s.kubeClient.SetApplyAttempts(cfg.ApplyAttempts)
+	s.kubeClient.SetConnection(kubernetes.ConnectionOptions{
+		Kubeconfig: cfg.KubeconfigPath,
+		Context:    cfg.KubeContext,
+		QPS:        cfg.KubeQPS,
+		Burst:      cfg.KubeBurst,
+	})
+	s.SetTLS(cfg.TLSCertFile, cfg.TLSKeyFile)
+	s.SetClientCA(cfg.TLSClientCA)
+
+	// Replayed events run the same business rules as the events seen by control
+	if cfg.EventReplay {
+		s.EnableEventReplay(handlers.NewResourceHandler(domain.NewResourceService(s.kubeClient)))
+	}
+	return s
+}
+
+// EnableEventReplay serves POST /api/v1/events/replay, which passes the posted event to handler.
+// It is a debug endpoint that runs business rules on demand, so it is off by default.
+// It must be called before SetupRoutes.
+func (s *Server) EnableEventReplay(handler kubernetes.ResourceEventHandler) {
+	s.kubeClient.SetEventHandler(handler)
+	s.eventReplay = true
+}
+
+// SetTLS makes the server serve HTTPS with the certificate and key in the given PEM files.
+// With either file empty the server serves plain HTTP.
+func (s *Server) SetTLS(certFile, keyFile string) {
+	s.tlsCertFile = certFile
+	s.tlsKeyFile = keyFile
+	s.certs = nil
+}
+
+// SetClientCA makes the HTTPS server require client certificates signed by one of the CAs in the
+// PEM file and reject connections without one. Start fails if it is set without TLS.
+func (s *Server) SetClientCA(caFile string) {
+	s.tlsClientCA = caFile
+	s.certs = nil
+}
+
+// TLSEnabled reports whether the server serves HTTPS
+func (s *Server) TLSEnabled() bool {
+	return s.tlsCertFile != "" && s.tlsKeyFile != ""
+}
+
+// loadCertificate loads the TLS key pair the first time it is needed
+func (s *Server) loadCertificate() error {
+	if s.certs != nil {
+		return nil
+	}
+	certs, err := newCertReloader(s.tlsCertFile, s.tlsKeyFile, s.tlsClientCA)
+	if err != nil {
+		return err
+	}
+	s.certs = certs
+	return nil
+}
+
 // SetupRoutes configures the HTTP routes
 func (s *Server) SetupRoutes() {
 	// API version prefix
@@ -60,30 +181,108 @@ func (s *Server) SetupRoutes() {
 		return
 	}
 
-	// Initialize informers for default namespace
+	// Initialize informers for the configured namespaces, the same ones the controller watches
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := s.kubeClient.InitializeInformers(ctx, []string{"default"}); err != nil {
+	if err := s.kubeClient.InitializeInformers(ctx, s.kubeClient.ResolveNamespaces(ctx)); err != nil {
 		slog.Warn("Failed to initialize informers", "error", err)
 		// Continue anyway, we'll use direct API calls
 	}
 
 	// Health check
 	s.app.Get("/health", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{"status": "ok", "timestamp": time.Now().Format(time.RFC3339)})
+		return c.JSON(fiber.Map{"status": "ok", "timestamp": time.Now().UTC().Format(time.RFC3339)})
 	})
 
 	// Deployments
 	api.Get("/deployments", s.deploymentCtrl.ListDeployments)
+	api.Put("/deployments/:name", s.deploymentCtrl.ApplyDeployment)
+	api.Get("/deployments/:name/history", s.deploymentCtrl.GetDeploymentHistory)
+	api.Post("/deployments/:name/rollback", s.deploymentCtrl.RollbackDeployment)
+
+	// ConfigMaps
+	api.Get("/configmaps", s.configMapCtrl.ListConfigMaps)
+	api.Get("/configmaps/:name", s.configMapCtrl.GetConfigMap)
+
+	// Ingresses
+	api.Get("/ingresses", s.ingressCtrl.ListIngresses)
+	api.Get("/ingresses/:name", s.ingressCtrl.GetIngress)
+
+	// Jobs
+	api.Get("/jobs", s.jobCtrl.ListJobs)
+	api.Get("/jobs/:name", s.jobCtrl.GetJob)
+	api.Get("/cronjobs", s.jobCtrl.ListCronJobs)
+	api.Get("/cronjobs/:name", s.jobCtrl.GetCronJob)
+
+	// Resource counts
+	api.Get("/summary", s.summaryCtrl.GetSummary)
+
+	// Deployment readiness
+	api.Get("/health/deployments", s.summaryCtrl.GetDeploymentHealth)
+
+	// Debug endpoint feeding events to the event handler
+	if s.eventReplay {
+		slog.Warn("Event replay endpoint enabled", "path", "/api/v1/events/replay")
+		api.Post("/events/replay", s.eventCtrl.ReplayEvent)
+	}
 }
 
-// Start begins listening for HTTP requests
+// Start begins listening for HTTP requests, over TLS when a certificate is configured
 func (s *Server) Start() error {
-	return s.app.Listen(fmt.Sprintf(":%d", s.port))
+	addr := fmt.Sprintf(":%d", s.port)
+	if !s.TLSEnabled() {
+		// Serving plain HTTP would silently drop the TLS or client certificate requirement
+		if s.tlsCertFile != "" || s.tlsKeyFile != "" {
+			return errors.New("TLS requires both a certificate and a key file")
+		}
+		if s.tlsClientCA != "" {
+			return errors.New("a client CA requires a TLS certificate and key file")
+		}
+		return s.app.Listen(addr)
+	}
+
+	// A listener with GetCertificate instead of app.ListenTLS, so the certificate can be reloaded
+	if err := s.loadCertificate(); err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	return s.app.Listener(tls.NewListener(listener, s.certs.TLSConfig()))
+}
+
+// StartWithContext begins listening for HTTP requests and shuts the server down
+// when the context is cancelled. It returns once the server has stopped.
+func (s *Server) StartWithContext(ctx context.Context) error {
+	// Pick up rotated certificates on SIGHUP
+	if s.TLSEnabled() {
+		if err := s.loadCertificate(); err != nil {
+			return err
+		}
+		go s.certs.reloadOnSIGHUP(ctx)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.Start()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		slog.Info("Context cancelled, shutting down server")
+		if err := s.Shutdown(); err != nil {
+			return err
+		}
+		return <-errCh
+	}
 }
 
 // Shutdown gracefully stops the server
 func (s *Server) Shutdown() error {
+	s.kubeClient.Stop()
 	return s.app.Shutdown()
 }
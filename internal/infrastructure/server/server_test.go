@@ -0,0 +1,59 @@
+package server
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s-controller/internal/infrastructure/config"
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+func TestPropagateRequestID(t *testing.T) {
+	app := fiber.New()
+	app.Use(requestid.New())
+	app.Use(propagateRequestID)
+
+	var got string
+	app.Get("/", func(c *fiber.Ctx) error {
+		got = kubernetes.RequestIDFromContext(c.UserContext())
+		return nil
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "req-42")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	if got != "req-42" {
+		t.Errorf("expected the handler context to carry req-42, got %q", got)
+	}
+	if resp.Header.Get(fiber.HeaderXRequestID) != "req-42" {
+		t.Errorf("expected the request ID to be echoed, got %q", resp.Header.Get(fiber.HeaderXRequestID))
+	}
+}
+
+func TestNewServerWithConfigDiscoversNamespaces(t *testing.T) {
+	cfg := config.Default()
+	cfg.DiscoverNamespaces = true
+	clientset := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+	)
+
+	s := NewServerWithConfig(cfg, kubernetes.WithClientset(clientset))
+	s.SetupRoutes()
+	t.Cleanup(s.kubeClient.Stop)
+
+	namespaces := s.kubeClient.WatchStatus().Namespaces
+	if len(namespaces) != 2 || namespaces[0] != "team-a" || namespaces[1] != "team-b" {
+		t.Errorf("expected the discovered namespaces team-a and team-b, got %v", namespaces)
+	}
+}
@@ -0,0 +1,123 @@
+// package server provides HTTP server functionality using Fiber
+package server
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"k8s-controller/internal/domain"
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+// SummaryController handles the resource summary endpoint
+type SummaryController struct {
+	client kubernetes.Client
+}
+
+// NewSummaryController creates a new summary controller
+func NewSummaryController(client kubernetes.Client) *SummaryController {
+	return &SummaryController{
+		client: client,
+	}
+}
+
+// GetSummary handles requests for resource counts in a namespace.
+// Without a namespace query parameter, counts are aggregated across all watched namespaces.
+// Namespaces that fail are reported in errors and leave a partial summary of the others.
+func (c *SummaryController) GetSummary(ctx *fiber.Ctx) error {
+	namespaces := []string{ctx.Query("namespace")}
+	if namespaces[0] == "" {
+		namespaces = c.client.WatchStatus().Namespaces
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx.UserContext(), 10*time.Second)
+	defer cancel()
+
+	var total domain.ResourceSummary
+	failed := forEachNamespace(namespaces, func(namespace string) error {
+		summary, err := c.client.SummarizeResources(reqCtx, namespace)
+		if err != nil {
+			slog.Error("Failed to summarize resources", "error", err, "namespace", namespace)
+			return err
+		}
+		total = total.Add(summary)
+		return nil
+	})
+	if len(failed) > 0 && len(failed) == len(namespaces) {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Failed to summarize resources",
+			"error":   failed[0].Error,
+			"errors":  failed,
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"status":     "success",
+		"namespaces": namespaces,
+		"summary":    total,
+		"partial":    len(failed) > 0,
+		"errors":     failed,
+	})
+}
+
+// GetDeploymentHealth handles requests for the aggregate readiness of deployments, read from
+// the informer cache. Without a namespace query parameter, all watched namespaces are included.
+// Namespaces that fail are reported in errors and leave a partial result of the others.
+func (c *SummaryController) GetDeploymentHealth(ctx *fiber.Ctx) error {
+	namespaces := []string{ctx.Query("namespace")}
+	if namespaces[0] == "" {
+		namespaces = c.client.WatchStatus().Namespaces
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx.UserContext(), 10*time.Second)
+	defer cancel()
+
+	var deployments []domain.Deployment
+	failed := forEachNamespace(namespaces, func(namespace string) error {
+		namespaceDeployments, err := c.client.ListDeployments(reqCtx, namespace)
+		if err != nil {
+			slog.Error("Failed to list deployments", "error", err, "namespace", namespace)
+			return err
+		}
+		deployments = append(deployments, namespaceDeployments...)
+		return nil
+	})
+	if len(failed) > 0 && len(failed) == len(namespaces) {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  "error",
+			"message": "Failed to list deployments",
+			"error":   failed[0].Error,
+			"errors":  failed,
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"status":     "success",
+		"namespaces": namespaces,
+		"health":     domain.SummarizeDeploymentHealth(deployments),
+		"partial":    len(failed) > 0,
+		"errors":     failed,
+	})
+}
+
+// namespaceError is a namespace whose part of an aggregate request failed
+type namespaceError struct {
+	Namespace string `json:"namespace"`
+	Error     string `json:"error"`
+}
+
+// forEachNamespace calls fn for every namespace, continuing past failures, and returns the
+// namespaces that failed. The result is empty, not nil, when all succeed.
+func forEachNamespace(namespaces []string, fn func(namespace string) error) []namespaceError {
+	failed := []namespaceError{}
+	for _, namespace := range namespaces {
+		if err := fn(namespace); err != nil {
+			failed = append(failed, namespaceError{Namespace: namespace, Error: err.Error()})
+		}
+	}
+	return failed
+}
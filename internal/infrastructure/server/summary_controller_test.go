@@ -0,0 +1,73 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"k8s-controller/internal/infrastructure/kubernetes"
+)
+
+func TestGetDeploymentHealthReturnsPartialResults(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-a"}},
+	)
+	clientset.PrependReactor("list", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() == "broken" {
+			return true, nil, errors.New("forbidden")
+		}
+		return false, nil, nil
+	})
+	client := kubernetes.NewClient(kubernetes.WithClientset(clientset))
+
+	app := fiber.New()
+	app.Get("/health/deployments", NewSummaryController(client).GetDeploymentHealth)
+
+	type response struct {
+		Status  string `json:"status"`
+		Partial bool   `json:"partial"`
+		Health  struct {
+			Total int `json:"total"`
+		} `json:"health"`
+		Errors []namespaceError `json:"errors"`
+	}
+	get := func(namespaces ...string) (int, response) {
+		t.Helper()
+		client.SetNamespaces(namespaces)
+		resp, err := app.Test(httptest.NewRequest("GET", "/health/deployments", nil))
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		var body response
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("invalid response: %v", err)
+		}
+		return resp.StatusCode, body
+	}
+
+	status, body := get("default", "team-a")
+	if status != fiber.StatusOK || body.Partial || len(body.Errors) != 0 || body.Health.Total != 2 {
+		t.Errorf("expected a complete result, got %d %+v", status, body)
+	}
+
+	status, body = get("default", "broken", "team-a")
+	if status != fiber.StatusOK || !body.Partial || body.Health.Total != 2 {
+		t.Errorf("expected a partial result of the healthy namespaces, got %d %+v", status, body)
+	}
+	if len(body.Errors) != 1 || body.Errors[0].Namespace != "broken" {
+		t.Errorf("expected an error for namespace broken, got %+v", body.Errors)
+	}
+
+	if status, _ := get("broken"); status != fiber.StatusInternalServerError {
+		t.Errorf("expected 500 when every namespace fails, got %d", status)
+	}
+}
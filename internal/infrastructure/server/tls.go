@@ -0,0 +1,115 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// certReloader serves the TLS certificate loaded from a cert and key file and replaces it when
+// reloaded, so certificates can be rotated without restarting the server. With a client CA file
+// it also requires client certificates signed by one of the CAs in it.
+type certReloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	mu           sync.RWMutex
+	cert         *tls.Certificate
+	clientCAs    *x509.CertPool
+}
+
+// newCertReloader loads the key pair and, if clientCAFile is set, the client CA bundle
+func newCertReloader(certFile, keyFile, clientCAFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile, clientCAFile: clientCAFile}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reads the key pair and client CA bundle again. On error the previous ones stay in use.
+func (r *certReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate %s: %w", r.certFile, err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA %s: %w", r.clientCAFile, err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("failed to load client CA %s: no PEM certificates found", r.clientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.mu.Unlock()
+	return nil
+}
+
+// GetCertificate implements tls.Config.GetCertificate
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// TLSConfig returns a server TLS config that always serves the latest loaded certificate and,
+// with a client CA, rejects connections without a client certificate signed by it
+func (r *certReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.GetCertificate,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.connectionConfig(), nil
+		},
+	}
+}
+
+// connectionConfig returns the TLS config for a new connection from the loaded files
+func (r *certReloader) connectionConfig() *tls.Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*r.cert},
+	}
+	if r.clientCAs != nil {
+		config.ClientCAs = r.clientCAs
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config
+}
+
+// reloadOnSIGHUP reloads the certificate on every SIGHUP until ctx is cancelled
+func (r *certReloader) reloadOnSIGHUP(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			if err := r.Reload(); err != nil {
+				slog.Error("Failed to reload TLS certificate, keeping the previous one", "error", err)
+				continue
+			}
+			slog.Info("Reloaded TLS certificate", "cert_file", r.certFile)
+		}
+	}
+}
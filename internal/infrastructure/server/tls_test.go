@@ -0,0 +1,202 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestCertificate writes a self-signed certificate for the common name and its key to dir
+func writeTestCertificate(t *testing.T, dir, commonName string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile = filepath.Join(dir, commonName+".crt")
+	keyFile = filepath.Join(dir, commonName+".key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+// copyFile replaces dst with the content of src
+func copyFile(t *testing.T, src, dst string) {
+	t.Helper()
+
+	data, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dst, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// servedCommonName returns the common name of the certificate the reloader currently serves
+func servedCommonName(t *testing.T, r *certReloader) string {
+	t.Helper()
+
+	cert, err := r.GetCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf.Subject.CommonName
+}
+
+func TestCertReloaderReload(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertificate(t, dir, "server")
+
+	reloader, err := newCertReloader(certFile, keyFile, "")
+	if err != nil {
+		t.Fatalf("failed to load certificate: %v", err)
+	}
+	first := servedCommonName(t, reloader)
+
+	// A rotated certificate is served after reloading
+	rotatedCert, rotatedKey := writeTestCertificate(t, t.TempDir(), "rotated")
+	copyFile(t, rotatedCert, certFile)
+	copyFile(t, rotatedKey, keyFile)
+	if err := reloader.Reload(); err != nil {
+		t.Fatalf("failed to reload certificate: %v", err)
+	}
+	if got := servedCommonName(t, reloader); got != "rotated" {
+		t.Errorf("expected certificate rotated after reload instead of %s, got %s", first, got)
+	}
+
+	// A broken file keeps the previous certificate
+	if err := os.WriteFile(certFile, []byte("garbage"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := reloader.Reload(); err == nil {
+		t.Error("expected reloading an invalid certificate to fail")
+	}
+	if got := servedCommonName(t, reloader); got != "rotated" {
+		t.Errorf("expected certificate rotated to stay in use, got %s", got)
+	}
+}
+
+func TestClientCARequiresClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	serverCert, serverKey := writeTestCertificate(t, dir, "server")
+	// The self-signed client certificate doubles as the client CA
+	clientCert, clientKey := writeTestCertificate(t, dir, "client")
+	otherCert, otherKey := writeTestCertificate(t, dir, "other")
+
+	reloader, err := newCertReloader(serverCert, serverKey, clientCert)
+	if err != nil {
+		t.Fatalf("failed to load certificates: %v", err)
+	}
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", reloader.TLSConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+
+	// dial completes a handshake and reads, which fails once the server rejects the certificate
+	dial := func(certFile, keyFile string) error {
+		config := &tls.Config{InsecureSkipVerify: true}
+		if certFile != "" {
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			config.Certificates = []tls.Certificate{cert}
+		}
+		conn, err := tls.Dial("tcp", listener.Addr().String(), config)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		_, err = conn.Read(make([]byte, 1))
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return err
+	}
+
+	if err := dial(clientCert, clientKey); err != nil {
+		t.Errorf("expected a client certificate signed by the CA to be accepted, got %v", err)
+	}
+	if err := dial("", ""); err == nil {
+		t.Error("expected a connection without a client certificate to be rejected")
+	}
+	if err := dial(otherCert, otherKey); err == nil {
+		t.Error("expected a client certificate from another CA to be rejected")
+	}
+}
+
+func TestTLSEnabledRequiresBothFiles(t *testing.T) {
+	s := &Server{}
+	s.SetTLS("tls.crt", "")
+	if s.TLSEnabled() {
+		t.Error("expected TLS to stay off without a key file")
+	}
+
+	s.SetTLS("tls.crt", "tls.key")
+	if !s.TLSEnabled() {
+		t.Error("expected TLS to be on with cert and key files")
+	}
+}
+
+func TestStartRejectsIncompleteTLS(t *testing.T) {
+	tests := map[string]func(s *Server){
+		"client CA without TLS": func(s *Server) { s.SetClientCA("ca.crt") },
+		"cert without key":      func(s *Server) { s.SetTLS("tls.crt", "") },
+	}
+	for name, configure := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := &Server{}
+			configure(s)
+			if err := s.Start(); err == nil {
+				t.Error("expected Start to refuse to serve plain HTTP")
+			}
+		})
+	}
+}
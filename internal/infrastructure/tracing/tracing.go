@@ -0,0 +1,80 @@
+// package tracing sets up OpenTelemetry tracing and the helpers used to create spans
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName is reported as service.name on every exported span
+const ServiceName = "k8s-controller"
+
+// Span attribute keys of the spans around Kubernetes operations
+const (
+	KindKey      = attribute.Key("k8s.resource.kind")
+	NameKey      = attribute.Key("k8s.resource.name")
+	NamespaceKey = attribute.Key("k8s.namespace.name")
+	// SourceKey tells where a list or get was answered from: label-index, informer-cache or api
+	SourceKey = attribute.Key("k8s_controller.source")
+)
+
+// Setup exports spans over OTLP/HTTP to endpoint, e.g. http://otel-collector:4318. With an empty
+// endpoint tracing stays a no-op. The returned function flushes and stops the exporter.
+func Setup(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer for the controller's spans. It follows the global provider, so
+// tracers obtained before Setup still export once it has run.
+func Tracer() trace.Tracer {
+	return otel.Tracer(ServiceName)
+}
+
+// Start starts a span for an operation on a Kubernetes object. Empty name or namespace
+// attributes are left out, e.g. for lists or cluster-scoped objects.
+func Start(ctx context.Context, spanName, kind, namespace, name string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{KindKey.String(kind)}
+	if namespace != "" {
+		attrs = append(attrs, NamespaceKey.String(namespace))
+	}
+	if name != "" {
+		attrs = append(attrs, NameKey.String(name))
+	}
+	return Tracer().Start(ctx, spanName, trace.WithAttributes(attrs...))
+}
+
+// End records err on the span, if any, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetupWithoutEndpointIsNoop(t *testing.T) {
+	before := otel.GetTracerProvider()
+
+	shutdown, err := Setup(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown failed: %v", err)
+	}
+	if otel.GetTracerProvider() != before {
+		t.Error("expected the global tracer provider to be left alone without an endpoint")
+	}
+}
+
+func TestStartRecordsResourceAttributes(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	_, span := Start(context.Background(), "Get deployments", "Deployment", "default", "web")
+	End(span, errors.New("not found"))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected one span, got %d", len(spans))
+	}
+
+	attrs := make(map[string]string)
+	for _, attr := range spans[0].Attributes {
+		attrs[string(attr.Key)] = attr.Value.AsString()
+	}
+	if attrs[string(KindKey)] != "Deployment" || attrs[string(NamespaceKey)] != "default" || attrs[string(NameKey)] != "web" {
+		t.Errorf("unexpected attributes %v", attrs)
+	}
+	if spans[0].Status.Code != codes.Error {
+		t.Errorf("expected an error status, got %v", spans[0].Status)
+	}
+}